@@ -0,0 +1,93 @@
+package trace
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// AdaptivePolicy configures WithAdaptiveLevel.
+type AdaptivePolicy struct {
+	// ErrorThreshold is the number of errors within Window that starts an incident.
+	ErrorThreshold int
+	// Window is the period errors are counted over. Defaults to one minute.
+	Window time.Duration
+	// IncidentLevel is the minimum enabled level during an incident. Above the logger's
+	// level it suppresses noise (WarnLevel drops Info); below it enables extra detail
+	// (DebugLevel). It is capped at ErrorLevel so errors are always logged.
+	IncidentLevel zapcore.Level
+	// Cooldown is how long an incident lasts after the last error beyond the
+	// threshold. Defaults to Window.
+	Cooldown time.Duration
+}
+
+// WithAdaptiveLevel adjusts the logger's effective level from its observed error
+// rate: once more than ErrorThreshold errors are logged within a Window, the minimum
+// enabled level switches to IncidentLevel until Cooldown passes without another error
+// beyond the threshold. A non-positive ErrorThreshold disables the policy.
+func WithAdaptiveLevel(p AdaptivePolicy) Option {
+	return func(o *options) {
+		if p.ErrorThreshold <= 0 {
+			o.adaptive = nil
+			return
+		}
+		if p.Window <= 0 {
+			p.Window = time.Minute
+		}
+		if p.Cooldown <= 0 {
+			p.Cooldown = p.Window
+		}
+		if p.IncidentLevel > zapcore.ErrorLevel {
+			p.IncidentLevel = zapcore.ErrorLevel
+		}
+		o.adaptive = &p
+	}
+}
+
+// adaptiveLevel is a LevelEnabler switching between a base and an incident level.
+type adaptiveLevel struct {
+	base   zapcore.LevelEnabler
+	policy AdaptivePolicy
+	until  atomic.Int64 // unix nanos at which the current incident ends; 0 when none
+
+	mu          sync.Mutex
+	windowStart time.Time
+	errors      int
+}
+
+func newAdaptiveLevel(base zapcore.LevelEnabler, p AdaptivePolicy) *adaptiveLevel {
+	return &adaptiveLevel{base: base, policy: p}
+}
+
+func (a *adaptiveLevel) Enabled(l zapcore.Level) bool {
+	if until := a.until.Load(); until != 0 {
+		if time.Now().UnixNano() < until {
+			return l >= a.policy.IncidentLevel
+		}
+		a.until.CompareAndSwap(until, 0)
+	}
+	return a.base.Enabled(l)
+}
+
+// observe counts written errors and starts or extends an incident when the
+// threshold is exceeded within the current window.
+func (a *adaptiveLevel) observe(ent zapcore.Entry) error {
+	if ent.Level < zapcore.ErrorLevel {
+		return nil
+	}
+	now := time.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if now.Sub(a.windowStart) >= a.policy.Window {
+		a.windowStart = now
+		a.errors = 0
+	}
+	a.errors++
+	if a.errors > a.policy.ErrorThreshold {
+		a.until.Store(now.Add(a.policy.Cooldown).UnixNano())
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package trace
+
+import "go.uber.org/zap"
+
+// Claims returns the standard fields describing an authenticated caller:
+// auth_subject, auth_scopes and auth_issuer. It deliberately accepts only
+// already-extracted claims, never the raw token, so credentials cannot end up in logs.
+func Claims(sub string, scopes []string, iss string) []zap.Field {
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return []zap.Field{
+		zap.String("auth_subject", sub),
+		zap.Strings("auth_scopes", scopes),
+		zap.String("auth_issuer", iss),
+	}
+}
@@ -0,0 +1,67 @@
+package trace
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newBenchLogger returns a logger with the package's full core stack writing JSON
+// to io.Discard, so benchmarks measure logging rather than output.
+func newBenchLogger(opts ...Option) Logger {
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(newJSONEncoderConfig()), zapcore.AddSync(io.Discard), zapcore.DebugLevel)
+	return NewWithCore(core, "bench", opts...)
+}
+
+func benchFields(n int) []zap.Field {
+	fields := make([]zap.Field, n)
+	for i := range fields {
+		fields[i] = zap.String(fmt.Sprintf("key%d", i), "value")
+	}
+	return fields
+}
+
+// BenchmarkWithVsPerCall compares binding fields once with With, binding them for
+// every entry (as a request-scoped logger created per call does) and passing them
+// on every call.
+func BenchmarkWithVsPerCall(b *testing.B) {
+	for _, n := range []int{2, 5, 10} {
+		fields := benchFields(n)
+
+		b.Run(fmt.Sprintf("With/%d", n), func(b *testing.B) {
+			logger := newBenchLogger().With(fields...)
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					logger.Info("benchmark")
+				}
+			})
+		})
+
+		b.Run(fmt.Sprintf("WithEachCall/%d", n), func(b *testing.B) {
+			logger := newBenchLogger()
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					logger.With(fields...).Info("benchmark")
+				}
+			})
+		})
+
+		b.Run(fmt.Sprintf("PerCall/%d", n), func(b *testing.B) {
+			logger := newBenchLogger()
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					logger.Info("benchmark", fields...)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package trace
+
+import (
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// BigStr logs a preview of a potentially large string, such as a request body or a
+// SQL statement, together with its fingerprint: key holds the first max characters
+// (runes), key_hash the first 8 hex digits of the SHA-256 of the full value, as in
+// Hashed, and key_len its total length in bytes. key_truncated is true when the
+// preview is shorter than the value. A negative max is treated as 0.
+func BigStr(key, val string, max int) zap.Field {
+	return zap.Inline(bigStr{key: key, val: val, max: max})
+}
+
+type bigStr struct {
+	key, val string
+	max      int
+}
+
+func (b bigStr) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	preview, truncated := b.val, false
+	if b.max < 0 {
+		b.max = 0
+	}
+	if utf8.RuneCountInString(b.val) > b.max {
+		n := 0
+		for i := range b.val {
+			if n == b.max {
+				preview = b.val[:i]
+				break
+			}
+			n++
+		}
+		truncated = true
+	}
+
+	enc.AddString(b.key, preview)
+	enc.AddString(b.key+"_hash", shortHash([]byte(b.val)))
+	enc.AddInt(b.key+"_len", len(b.val))
+	if truncated {
+		enc.AddBool(b.key+"_truncated", true)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package trace
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// DefaultBufferSize is used by WithBuffer when size is not positive.
+	DefaultBufferSize = 256 * 1024
+	// DefaultFlushInterval is used by WithBuffer when interval is not positive.
+	DefaultFlushInterval = 30 * time.Second
+)
+
+// WithBuffer holds output in memory and writes it in batches, once size bytes have
+// accumulated or interval has passed since the first unflushed write, whichever
+// comes first. Entries above Error level and calls to Sync flush immediately.
+// Buffered output that has not been flushed is lost if the process exits without
+// syncing the logger. Buffer health is reported by BufferStats.
+func WithBuffer(size int, interval time.Duration) Option {
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+	return func(o *options) {
+		o.bufferSize = size
+		o.flushInterval = interval
+	}
+}
+
+// BufferMetrics describes the health of buffered output across all loggers.
+type BufferMetrics struct {
+	Buffered     int64         // bytes currently waiting to be flushed
+	Flushes      uint64        // completed flushes
+	FlushLatency time.Duration // average time spent writing a flush
+	Dropped      uint64        // bytes discarded because a flush failed
+}
+
+var bufferMetrics struct {
+	buffered     atomic.Int64
+	flushes      atomic.Uint64
+	flushLatency atomic.Int64 // total nanoseconds
+	dropped      atomic.Uint64
+}
+
+// BufferStats returns a snapshot of buffered output metrics, aggregated across every
+// logger built with WithBuffer. Operators can log or export it periodically to see
+// whether buffers keep up with load.
+func BufferStats() BufferMetrics {
+	m := BufferMetrics{
+		Buffered: bufferMetrics.buffered.Load(),
+		Flushes:  bufferMetrics.flushes.Load(),
+		Dropped:  bufferMetrics.dropped.Load(),
+	}
+	if m.Flushes > 0 {
+		m.FlushLatency = time.Duration(bufferMetrics.flushLatency.Load() / int64(m.Flushes))
+	}
+	return m
+}
+
+// bufferedSink batches writes to ws. Instead of a ticker goroutine it arms a
+// timer when the buffer becomes non-empty, so idle loggers hold no goroutines.
+type bufferedSink struct {
+	mu       sync.Mutex
+	ws       zapcore.WriteSyncer
+	buf      []byte
+	size     int
+	interval time.Duration
+	timer    *time.Timer
+}
+
+func newBufferedSink(ws zapcore.WriteSyncer, size int, interval time.Duration) *bufferedSink {
+	return &bufferedSink{ws: ws, buf: make([]byte, 0, size), size: size, interval: interval}
+}
+
+func (s *bufferedSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.buf)+len(p) > s.size {
+		if err := s.flushLocked(); err != nil {
+			return 0, err
+		}
+	}
+	// Oversized writes bypass the buffer entirely.
+	if len(p) > s.size {
+		return s.ws.Write(p)
+	}
+
+	s.buf = append(s.buf, p...)
+	bufferMetrics.buffered.Add(int64(len(p)))
+	if s.timer == nil {
+		s.timer = time.AfterFunc(s.interval, s.flushOnTimer)
+	}
+	return len(p), nil
+}
+
+func (s *bufferedSink) Sync() error {
+	s.mu.Lock()
+	err := s.flushLocked()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return s.ws.Sync()
+}
+
+func (s *bufferedSink) flushOnTimer() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timer = nil
+	_ = s.flushLocked()
+}
+
+func (s *bufferedSink) flushLocked() error {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if len(s.buf) == 0 {
+		return nil
+	}
+
+	n := len(s.buf)
+	start := time.Now()
+	_, err := s.ws.Write(s.buf)
+	bufferMetrics.flushLatency.Add(int64(time.Since(start)))
+	bufferMetrics.flushes.Add(1)
+	bufferMetrics.buffered.Add(-int64(n))
+	if err != nil {
+		bufferMetrics.dropped.Add(uint64(n))
+	}
+	s.buf = s.buf[:0]
+	return err
+}
@@ -0,0 +1,48 @@
+package trace
+
+import (
+	"runtime/debug"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Commit returns a commit field for the given VCS revision.
+func Commit(sha string) zap.Field {
+	return zap.String("commit", sha)
+}
+
+// CommitFromBuildInfo returns a commit field with the VCS revision embedded by the
+// Go toolchain (see debug.ReadBuildInfo), suffixed with "-dirty" when the working
+// tree had local modifications. It returns an empty field when the binary carries
+// no VCS information, e.g. when built with -buildvcs=false or via go run.
+// Bind it on the root logger so every line identifies the exact commit:
+//
+//	logger = logger.With(trace.CommitFromBuildInfo())
+func CommitFromBuildInfo() zap.Field {
+	if rev := buildRevision(); rev != "" {
+		return Commit(rev)
+	}
+	return zap.Skip()
+}
+
+var buildRevision = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	var rev string
+	var dirty bool
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			rev = s.Value
+		case "vcs.modified":
+			dirty = s.Value == "true"
+		}
+	}
+	if rev != "" && dirty {
+		rev += "-dirty"
+	}
+	return rev
+})
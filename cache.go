@@ -0,0 +1,62 @@
+package trace
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// CacheEvent returns the standard fields of a cache lookup: cache_name and
+// cache_result ("hit" or "miss"), followed by fields such as the key or TTL, so hit
+// rates can be computed directly from logs.
+func CacheEvent(name string, hit bool, fields ...zap.Field) []zap.Field {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	out := make([]zap.Field, 0, len(fields)+2)
+	out = append(out, zap.String("cache_name", name), zap.String("cache_result", result))
+	return append(out, fields...)
+}
+
+// CacheStats aggregates the hits and misses of one cache for periodic summary logs,
+// where logging every lookup would be too verbose. It is safe for concurrent use.
+//
+//	stats := trace.NewCacheStats("users")
+//	stats.Record(hit) // on every lookup
+//	logger.Info("cache summary", stats.Summary()...) // e.g. once a minute
+type CacheStats struct {
+	name         string
+	hits, misses atomic.Int64
+}
+
+// NewCacheStats returns empty statistics for the named cache.
+func NewCacheStats(name string) *CacheStats {
+	return &CacheStats{name: name}
+}
+
+// Record counts one lookup.
+func (s *CacheStats) Record(hit bool) {
+	if hit {
+		s.hits.Add(1)
+	} else {
+		s.misses.Add(1)
+	}
+}
+
+// Summary returns cache_name, cache_hits, cache_misses and cache_hit_rate (percent of
+// lookups that hit, 0 without lookups) for the window since the previous Summary,
+// and starts a new window.
+func (s *CacheStats) Summary() []zap.Field {
+	hits, misses := s.hits.Swap(0), s.misses.Swap(0)
+	rate := 0.0
+	if total := hits + misses; total > 0 {
+		rate = float64(hits) / float64(total) * 100
+	}
+	return []zap.Field{
+		zap.String("cache_name", s.name),
+		zap.Int64("cache_hits", hits),
+		zap.Int64("cache_misses", misses),
+		zap.Float64("cache_hit_rate", rate),
+	}
+}
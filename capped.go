@@ -0,0 +1,46 @@
+package trace
+
+import (
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// StrsCapped logs at most max elements of vals under key, followed by a "<N more>"
+// element when some were left out, and the total length under key_len, keeping list
+// context without blowing up the entry. A negative max is treated as 0.
+func StrsCapped(key string, vals []string, max int) zap.Field {
+	return zap.Inline(cappedSlice[string]{key: key, vals: vals, max: max, add: zapcore.PrimitiveArrayEncoder.AppendString})
+}
+
+// IntsCapped is StrsCapped for ints.
+func IntsCapped(key string, vals []int, max int) zap.Field {
+	return zap.Inline(cappedSlice[int]{key: key, vals: vals, max: max, add: zapcore.PrimitiveArrayEncoder.AppendInt})
+}
+
+type cappedSlice[T any] struct {
+	key  string
+	vals []T
+	max  int
+	add  func(zapcore.PrimitiveArrayEncoder, T)
+}
+
+func (c cappedSlice[T]) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if err := enc.AddArray(c.key, c); err != nil {
+		return err
+	}
+	enc.AddInt(c.key+"_len", len(c.vals))
+	return nil
+}
+
+func (c cappedSlice[T]) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	n := min(len(c.vals), max(c.max, 0))
+	for _, v := range c.vals[:n] {
+		c.add(enc, v)
+	}
+	if more := len(c.vals) - n; more > 0 {
+		enc.AppendString("<" + strconv.Itoa(more) + " more>")
+	}
+	return nil
+}
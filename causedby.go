@@ -0,0 +1,9 @@
+package trace
+
+import "go.uber.org/zap"
+
+// CausedBy links an entry to an earlier one it follows from, such as a retry
+// referencing the original failure, by logging parentID under caused_by.
+func CausedBy(parentID string) zap.Field {
+	return zap.String("caused_by", parentID)
+}
@@ -0,0 +1,70 @@
+package trace
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// LoggedEntry is an entry delivered by a channel logger. Context holds the fields
+// bound via With followed by those of the call; Dropped counts the entries
+// discarded so far because the channel was full.
+type LoggedEntry struct {
+	zapcore.Entry
+	Context []zapcore.Field
+	Dropped uint64
+}
+
+// ContextMap returns the entry's fields as a map, as FieldsToMap does.
+func (e LoggedEntry) ContextMap() map[string]interface{} {
+	return FieldsToMap(e.Context...)
+}
+
+// NewChannelLogger creates a Debug-level logger that delivers its entries on the
+// returned channel instead of writing them, for consumers such as live log viewers
+// or custom processors. The channel holds up to buf entries; when it is full,
+// entries are dropped rather than blocking the caller, and the next delivered entry
+// reports the total dropped. The channel is never closed.
+func NewChannelLogger(buf int, opts ...Option) (Logger, <-chan LoggedEntry) {
+	if buf < 0 {
+		buf = 0
+	}
+	o := newOptions(opts)
+	ch := make(chan LoggedEntry, buf)
+	enab := o.levelEnabler(zapcore.DebugLevel)
+	core := &channelCore{LevelEnabler: enab, ch: ch, dropped: &atomic.Uint64{}}
+
+	cfg := &loggerConfig{sinks: []sinkInfo{{name: "channel", level: enab}}}
+	return newLogger(core, "", cfg, o), ch
+}
+
+// channelCore sends entries to a channel without blocking.
+type channelCore struct {
+	zapcore.LevelEnabler
+	ch      chan LoggedEntry
+	context []zapcore.Field
+	dropped *atomic.Uint64
+}
+
+func (c *channelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &channelCore{LevelEnabler: c.LevelEnabler, ch: c.ch, context: appendFields(c.context, fields), dropped: c.dropped}
+}
+
+func (c *channelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *channelCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	e := LoggedEntry{Entry: ent, Context: appendFields(c.context, fields), Dropped: c.dropped.Load()}
+	select {
+	case c.ch <- e:
+	default:
+		c.dropped.Add(1)
+	}
+	return nil
+}
+
+func (c *channelCore) Sync() error { return nil }
@@ -0,0 +1,32 @@
+package trace
+
+import "testing"
+
+func TestChannelLogger(t *testing.T) {
+	logger, ch := NewChannelLogger(2)
+	child := logger.With(Str("request_id", "r1"))
+
+	child.Debug("one", Int("n", 1))
+	child.Info("two")
+	child.Info("dropped")
+	child.Info("dropped too")
+
+	first := <-ch
+	if first.Message != "one" || first.Dropped != 0 {
+		t.Errorf("first = %q dropped %d, want \"one\" dropped 0", first.Message, first.Dropped)
+	}
+	if m := first.ContextMap(); m["request_id"] != "r1" || m["n"] != int64(1) {
+		t.Errorf("first fields = %v, want bound and call fields", m)
+	}
+	<-ch
+
+	child.Warn("after drain")
+	if e := <-ch; e.Message != "after drain" || e.Dropped != 2 {
+		t.Errorf("entry = %q dropped %d, want \"after drain\" dropped 2", e.Message, e.Dropped)
+	}
+	select {
+	case e := <-ch:
+		t.Errorf("unexpected entry %q", e.Message)
+	default:
+	}
+}
@@ -0,0 +1,32 @@
+package trace
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ChanStats logs the fill state of a channel or worker pool queue as a nested
+// object with length, capacity and utilization (percent of capacity in use),
+// standardizing how queue health and backpressure are reported:
+//
+//	logger.Info("queue", trace.ChanStats("jobs", len(jobs), cap(jobs)))
+//
+// Utilization is 0 for unbuffered (zero-capacity) queues.
+func ChanStats(key string, length, capacity int) zap.Field {
+	return zap.Object(key, chanStats{length: length, capacity: capacity})
+}
+
+type chanStats struct {
+	length, capacity int
+}
+
+func (c chanStats) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("length", c.length)
+	enc.AddInt("capacity", c.capacity)
+	utilization := 0.0
+	if c.capacity > 0 {
+		utilization = float64(c.length) / float64(c.capacity) * 100
+	}
+	enc.AddFloat64("utilization", utilization)
+	return nil
+}
@@ -0,0 +1,86 @@
+package trace
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// maskedValue replaces the value of fields tagged `log:"secret"`.
+const maskedValue = "***"
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// ConfigDump logs the exported fields of a config struct, recursing into nested
+// structs, so the effective configuration can be logged safely at startup.
+// Struct tags control each field:
+//
+//	Password string `log:"secret"` // logged as "***" (or "" when unset)
+//	Internal string `log:"-"`      // omitted
+//
+// Non-struct values are logged as with zap.Any.
+func ConfigDump(key string, cfg interface{}) zap.Field {
+	v := indirectValue(reflect.ValueOf(cfg))
+	if !v.IsValid() || v.Kind() != reflect.Struct || v.Type() == timeType {
+		return zap.Any(key, cfg)
+	}
+	return zap.Object(key, configMarshaler{v: v})
+}
+
+type configMarshaler struct {
+	v reflect.Value
+}
+
+func (c configMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	t := c.v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		tag, _, _ := strings.Cut(f.Tag.Get("log"), ",")
+		switch tag {
+		case "-":
+			continue
+		case "secret":
+			enc.AddString(f.Name, mask(c.v.Field(i)))
+			continue
+		}
+
+		fv := indirectValue(c.v.Field(i))
+		switch {
+		case !fv.IsValid():
+			if err := enc.AddReflected(f.Name, nil); err != nil {
+				return err
+			}
+		case fv.Type() == timeType:
+			enc.AddTime(f.Name, fv.Interface().(time.Time))
+		case fv.Type() == durationType:
+			enc.AddDuration(f.Name, time.Duration(fv.Int()))
+		case fv.Kind() == reflect.Struct:
+			if err := enc.AddObject(f.Name, configMarshaler{v: fv}); err != nil {
+				return err
+			}
+		default:
+			if err := enc.AddReflected(f.Name, fv.Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mask hides a secret value while still revealing whether it is set.
+func mask(v reflect.Value) string {
+	if v.IsZero() {
+		return ""
+	}
+	return maskedValue
+}
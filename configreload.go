@@ -0,0 +1,29 @@
+package trace
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ConfigReload returns the standard fields of a configuration reload event:
+// config_source (a file path, URL or provider name), config_changed with the keys
+// that changed and config_reload_time, followed by fields. Pair it with Diff to
+// record old and new values:
+//
+//	logger.Info("config reloaded", trace.ConfigReload("/etc/app.yaml", []string{"timeout"},
+//		trace.Diff("diff", oldCfg, newCfg))...)
+//
+// A nil changed is logged as an empty list.
+func ConfigReload(source string, changed []string, fields ...zap.Field) []zap.Field {
+	if changed == nil {
+		changed = []string{}
+	}
+	out := make([]zap.Field, 0, len(fields)+3)
+	out = append(out,
+		zap.String("config_source", source),
+		zap.Strings("config_changed", changed),
+		zap.Time("config_reload_time", time.Now()),
+	)
+	return append(out, fields...)
+}
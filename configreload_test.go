@@ -0,0 +1,27 @@
+package trace
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfigReload(t *testing.T) {
+	before := time.Now()
+	got := encodeJSON(t, ConfigReload("/etc/app.yaml", []string{"timeout", "workers"}, Str("actor", "ops"))...)
+
+	if got["config_source"] != "/etc/app.yaml" || got["actor"] != "ops" {
+		t.Errorf("fields = %v, want source and extra field", got)
+	}
+	if want := []interface{}{"timeout", "workers"}; !reflect.DeepEqual(got["config_changed"], want) {
+		t.Errorf("config_changed = %v, want %v", got["config_changed"], want)
+	}
+	ts, ok := got["config_reload_time"].(float64)
+	if !ok || ts < float64(before.UnixNano())/1e9-1 {
+		t.Errorf("config_reload_time = %v, want the current time", got["config_reload_time"])
+	}
+
+	if empty := encodeJSON(t, ConfigReload("env", nil)...); !reflect.DeepEqual(empty["config_changed"], []interface{}{}) {
+		t.Errorf("nil changed = %v, want an empty list", empty["config_changed"])
+	}
+}
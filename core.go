@@ -0,0 +1,68 @@
+package trace
+
+import (
+	"os"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// errorOutput receives write errors from cores that re-dispatch entries,
+// matching zap's default of reporting them on stderr.
+var errorOutput = zapcore.Lock(os.Stderr)
+
+// rewriteFunc transforms an entry and its complete field set before encoding.
+// Returning false drops the entry.
+type rewriteFunc func(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field, bool)
+
+// rewriteCore holds fields bound via With until Write so that fn sees every field
+// of an entry, not only those passed at the call site. The trade-off is that bound
+// fields are re-encoded for each entry instead of once when With is called.
+type rewriteCore struct {
+	zapcore.Core
+	fields []zapcore.Field
+	fn     rewriteFunc
+}
+
+func newRewriteCore(core zapcore.Core, fn rewriteFunc) zapcore.Core {
+	return &rewriteCore{Core: core, fn: fn}
+}
+
+func (c *rewriteCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rewriteCore{
+		Core:   c.Core,
+		fields: appendFields(c.fields, fields),
+		fn:     c.fn,
+	}
+}
+
+func (c *rewriteCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *rewriteCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent, all, ok := c.fn(ent, appendFields(c.fields, fields))
+	if !ok {
+		return nil
+	}
+	writeChecked(c.Core, ent, all)
+	return nil
+}
+
+// writeChecked dispatches an entry through core.Check rather than core.Write so
+// that filtering done by inner cores (per-sink levels, sampling) still applies.
+func writeChecked(core zapcore.Core, ent zapcore.Entry, fields []zapcore.Field) {
+	if ce := core.Check(ent, nil); ce != nil {
+		ce.ErrorOutput = errorOutput
+		ce.Write(fields...)
+	}
+}
+
+// appendFields returns a new slice holding a followed by b, never aliasing a.
+func appendFields(a, b []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, 0, len(a)+len(b))
+	out = append(out, a...)
+	return append(out, b...)
+}
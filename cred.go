@@ -0,0 +1,44 @@
+package trace
+
+import (
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// secretKeyParts are substrings of field keys that suggest secret material.
+var secretKeyParts = []string{"secret", "password", "passwd", "passphrase", "token", "private", "credential", "apikey", "api_key"}
+
+// CredEvent returns the standard fields of a credential lifecycle event for security
+// auditing: cred_action (e.g. "created", "rotated", "revoked"), cred_key_id and
+// cred_time, followed by fields. It never carries secret material: any field whose
+// key looks like a secret (containing "secret", "password", "token" and the like,
+// case-insensitively) is replaced by a "***" value under the same key.
+//
+//	logger.Info("api key rotated", trace.CredEvent("rotated", key.ID, zap.String("owner", owner))...)
+func CredEvent(action, keyID string, fields ...zap.Field) []zap.Field {
+	out := make([]zap.Field, 0, len(fields)+3)
+	out = append(out,
+		zap.String("cred_action", action),
+		zap.String("cred_key_id", keyID),
+		zap.Time("cred_time", time.Now()),
+	)
+	for _, f := range fields {
+		if isSecretKey(f.Key) {
+			f = zap.String(f.Key, maskedValue)
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package trace
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// CtxFields snapshots the values of ctx stored under the given keys, returning a field
+// per present key. The keys must have been stored as plain strings, as in
+// context.WithValue(ctx, "request_id", id); values stored under other key types,
+// including named string types, are not found. A nil ctx yields no fields.
+func CtxFields(ctx context.Context, keys ...string) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	var fields []zap.Field
+	for _, k := range keys {
+		if v := ctx.Value(k); v != nil {
+			fields = append(fields, Any(k, v))
+		}
+	}
+	return fields
+}
@@ -0,0 +1,51 @@
+package trace
+
+import "go.uber.org/zap/zapcore"
+
+// WithDedupeFields keeps only the last field of each key within an entry, such as a
+// key bound via With and passed again at the call site, so JSON output never holds
+// duplicate keys. Fields after a zap.Namespace are deduplicated within that namespace.
+// Fields without a key (such as inline objects) are always kept. It is opt-in because
+// bound fields are re-encoded per entry and every entry pays for the scan.
+func WithDedupeFields() Option {
+	return wrapCore(func(core zapcore.Core) zapcore.Core {
+		return newRewriteCore(core, func(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field, bool) {
+			return ent, dedupeFields(fields), true
+		})
+	})
+}
+
+// dedupeFields drops every field whose key appears again later in the same namespace.
+func dedupeFields(fields []zapcore.Field) []zapcore.Field {
+	keep := make([]bool, len(fields))
+	seen := make(map[string]struct{}, len(fields))
+	dropped := false
+	for i := len(fields) - 1; i >= 0; i-- {
+		f := fields[i]
+		switch {
+		case f.Type == zapcore.NamespaceType:
+			// Keys before a namespace live in the enclosing scope.
+			clear(seen)
+			keep[i] = true
+		case f.Key == "":
+			keep[i] = true
+		default:
+			if _, ok := seen[f.Key]; ok {
+				dropped = true
+				continue
+			}
+			seen[f.Key] = struct{}{}
+			keep[i] = true
+		}
+	}
+	if !dropped {
+		return fields
+	}
+	out := make([]zapcore.Field, 0, len(fields))
+	for i, f := range fields {
+		if keep[i] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
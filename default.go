@@ -1,11 +1,122 @@
 package trace
 
-var defaultLogger = NewNoopLogger()
+import (
+	"sync/atomic"
 
+	"go.uber.org/zap"
+)
+
+// defaultLogger and fallbackLogger are read on every package-level call, so they
+// are stored atomically rather than behind a lock. A nil defaultLogger means the
+// application has not called SetDefaultLogger.
+var (
+	defaultLogger  atomic.Pointer[Logger]
+	fallbackLogger atomic.Pointer[Logger]
+	noopLogger     = NewNoopLogger()
+)
+
+// SetDefaultLogger sets the logger used by the package-level functions.
+// Passing nil, including a typed nil pointer, installs a NoopLogger.
+// It is safe to call concurrently with logging.
 func SetDefaultLogger(logger Logger) {
-	defaultLogger = logger
+	if isNil(logger) {
+		logger = NewNoopLogger()
+	}
+	defaultLogger.Store(&logger)
 }
 
+// GetDefaultLogger returns the logger used by the package-level functions:
+// the one passed to SetDefaultLogger, or the fallback logger while none was set.
 func GetDefaultLogger() Logger {
-	return defaultLogger
+	if l := defaultLogger.Load(); l != nil {
+		return *l
+	}
+	if l := fallbackLogger.Load(); l != nil {
+		return *l
+	}
+	return noopLogger
+}
+
+// NamedDefault returns a named child of the current default logger for a subsystem,
+// sharing its sinks and level. It falls back to a NoopLogger when the default
+// logger has no zap logger behind it.
+func NamedDefault(name string) Logger {
+	return NewChildLogger(GetDefaultLogger(), name)
+}
+
+// With returns a child of the current default logger with fields bound to every
+// entry. The child keeps the logger it was derived from; later calls to
+// SetDefaultLogger do not affect it.
+func With(fields ...zap.Field) Logger {
+	return GetDefaultLogger().With(fields...)
+}
+
+// SetFallbackLogger registers a logger used by the package-level functions until the
+// application calls SetDefaultLogger. Libraries can use it to emit logs during early
+// init without overriding the application's configuration later.
+func SetFallbackLogger(logger Logger) {
+	if isNil(logger) {
+		fallbackLogger.Store(nil)
+		return
+	}
+	fallbackLogger.Store(&logger)
+}
+
+// Reset restores every package-level global to its initial state.
+// It is intended as a single teardown call for tests and is safe to call concurrently.
+func Reset() {
+	defaultLogger.Store(nil)
+	fallbackLogger.Store(nil)
+
+	resetFieldEncoders()
+	resetTenantContextKey()
+	resetLatencyBuckets()
+	warnedOnce.Clear()
+	errorPolicy.Store(nil)
+	fatalBehavior.Store(int32(ExitOnFatal))
+	fatalExit.Store(nil)
+	resetPause()
+	registry.Clear()
+}
+
+// Package-level logging through the default logger
+
+// defaultForCaller returns the default logger, adjusted under WithCaller so that the
+// reported caller is the code calling the package-level function rather than this file.
+func defaultForCaller() Logger {
+	l := GetDefaultLogger()
+	if sl, ok := l.(*sugarLogger); ok && sl.cfg != nil && sl.cfg.caller {
+		return &sugarLogger{log: sl.log.WithOptions(zap.AddCallerSkip(1)), cfg: sl.cfg, op: sl.op, opBase: sl.opBase, bound: sl.bound, gate: sl.gate}
+	}
+	return l
+}
+
+// Debug logs a debug message with the default logger
+func Debug(msg string, fields ...zap.Field) {
+	defaultForCaller().Debug(msg, fields...)
+}
+
+// Info logs an info message with the default logger
+func Info(msg string, fields ...zap.Field) {
+	defaultForCaller().Info(msg, fields...)
+}
+
+// Warn logs a warning message with the default logger
+func Warn(msg string, fields ...zap.Field) {
+	defaultForCaller().Warn(msg, fields...)
+}
+
+// Error logs an error message with the default logger
+func Error(msg string, fields ...zap.Field) {
+	defaultForCaller().Error(msg, fields...)
+}
+
+// Fatal logs a fatal message with the default logger and exits
+func Fatal(msg string, fields ...zap.Field) {
+	defaultForCaller().Fatal(msg, fields...)
+}
+
+// Sync flushes the default logger; see Logger.Sync.
+func Sync() error {
+	return GetDefaultLogger().Sync()
 }
@@ -0,0 +1,39 @@
+package trace
+
+import (
+	"sync"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDefaultLoggerConcurrentSet(t *testing.T) {
+	defer Reset()
+	const loggers, iterations = 8, 200
+
+	var wg sync.WaitGroup
+	for i := 0; i < loggers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				Info("concurrent", Int("n", j))
+				Infof("concurrent %d", j)
+				if GetDefaultLogger() == nil {
+					t.Error("GetDefaultLogger returned nil")
+					return
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j < iterations; j++ {
+			core, _ := observer.New(zapcore.InfoLevel)
+			SetDefaultLogger(NewWithCore(core, ""))
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,72 @@
+package trace
+
+import (
+	"reflect"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Diff logs a shallow, field-level diff between two values of the same struct type.
+// Only exported fields whose values differ are emitted, each as {"old": ..., "new": ...}.
+// Pointers are dereferenced; any other input (non-structs, mismatched types, nil)
+// is logged as plain "before" and "after" values.
+// The diff is computed lazily, only when the entry is actually encoded.
+func Diff(key string, before, after interface{}) zap.Field {
+	return zap.Object(key, diffMarshaler{before: before, after: after})
+}
+
+type diffMarshaler struct {
+	before, after interface{}
+}
+
+func (d diffMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	b := indirectValue(reflect.ValueOf(d.before))
+	a := indirectValue(reflect.ValueOf(d.after))
+
+	if !b.IsValid() || !a.IsValid() || b.Kind() != reflect.Struct || b.Type() != a.Type() {
+		if err := enc.AddReflected("before", d.before); err != nil {
+			return err
+		}
+		return enc.AddReflected("after", d.after)
+	}
+
+	t := b.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		oldVal, newVal := b.Field(i).Interface(), a.Field(i).Interface()
+		if reflect.DeepEqual(oldVal, newVal) {
+			continue
+		}
+		if err := enc.AddObject(f.Name, changeMarshaler{oldVal: oldVal, newVal: newVal}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type changeMarshaler struct {
+	oldVal, newVal interface{}
+}
+
+func (c changeMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if err := enc.AddReflected("old", c.oldVal); err != nil {
+		return err
+	}
+	return enc.AddReflected("new", c.newVal)
+}
+
+// indirectValue follows pointers until it reaches a non-pointer value.
+// A nil pointer yields the zero reflect.Value.
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
@@ -0,0 +1,26 @@
+package trace
+
+import "go.uber.org/zap/zapcore"
+
+// dynamicCore appends a field whose value is re-read from fn for every entry written.
+type dynamicCore struct {
+	zapcore.Core
+	key string
+	fn  func() interface{}
+}
+
+func (c *dynamicCore) With(fields []zapcore.Field) zapcore.Core {
+	return &dynamicCore{Core: c.Core.With(fields), key: c.key, fn: c.fn}
+}
+
+func (c *dynamicCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *dynamicCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	writeChecked(c.Core, ent, appendFields(fields, []zapcore.Field{Any(c.key, c.fn())}))
+	return nil
+}
@@ -0,0 +1,47 @@
+package trace
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.uber.org/zap"
+)
+
+// Enum logs a typed constant by its name. It is zap.Stringer under a name that states
+// the intent; a nil val, including a typed nil pointer, logs "<nil>".
+func Enum(key string, val fmt.Stringer) zap.Field {
+	if isNil(val) {
+		return zap.String(key, "<nil>")
+	}
+	return zap.Stringer(key, val)
+}
+
+// RegisterEnum names the values of an integer enum type t that has no String method,
+// so Any logs them by name: values missing from names are logged like "Color(7)".
+// It registers a FieldEncoder for t and panics if t is not an integer type.
+func RegisterEnum(t reflect.Type, names map[int]string) {
+	if t == nil {
+		return
+	}
+	var toInt func(reflect.Value) int
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		toInt = func(v reflect.Value) int { return int(v.Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		toInt = func(v reflect.Value) int { return int(v.Uint()) }
+	default:
+		panic(fmt.Sprintf("trace: RegisterEnum of non-integer type %s", t))
+	}
+
+	copied := make(map[int]string, len(names))
+	for k, v := range names {
+		copied[k] = v
+	}
+	RegisterFieldEncoder(t, func(key string, v interface{}) zap.Field {
+		n := toInt(reflect.ValueOf(v))
+		if name, ok := copied[n]; ok {
+			return zap.String(key, name)
+		}
+		return zap.String(key, fmt.Sprintf("%s(%d)", t.Name(), n))
+	})
+}
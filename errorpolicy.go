@@ -0,0 +1,68 @@
+package trace
+
+import (
+	"os"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ErrorPolicy decides what happens after an entry at Error level is logged.
+type ErrorPolicy struct {
+	exit bool
+	code int
+}
+
+// Continue is the default policy: logging an error has no side effects.
+var Continue = ErrorPolicy{}
+
+// ExitOnError is a fail-fast policy for scripts and CLI tools, like "set -e": the
+// first entry logged at Error or DPanic flushes the logger and exits with code.
+func ExitOnError(code int) ErrorPolicy {
+	return ErrorPolicy{exit: true, code: code}
+}
+
+// errorPolicy is the policy set by SetErrorPolicy; nil means Continue.
+var errorPolicy atomic.Pointer[ErrorPolicy]
+
+// SetErrorPolicy sets the policy applied by every logger built by this package
+// when it writes an entry at Error or DPanic. Entries that are not written, such as
+// those of a silenced logger, do not trigger it. Panic and Fatal keep their behaviour.
+func SetErrorPolicy(policy ErrorPolicy) {
+	if !policy.exit {
+		errorPolicy.Store(nil)
+		return
+	}
+	errorPolicy.Store(&policy)
+}
+
+// errorPolicyCore attaches the error policy to the entries it checks.
+type errorPolicyCore struct {
+	zapcore.Core
+}
+
+func (c *errorPolicyCore) With(fields []zapcore.Field) zapcore.Core {
+	return &errorPolicyCore{Core: c.Core.With(fields)}
+}
+
+func (c *errorPolicyCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	ce = c.Core.Check(ent, ce)
+	if ce == nil || ent.Level < zapcore.ErrorLevel || ent.Level > zapcore.DPanicLevel {
+		return ce
+	}
+	if p := errorPolicy.Load(); p != nil {
+		ce = ce.After(ent, exitHook{core: c.Core, code: p.code})
+	}
+	return ce
+}
+
+// exitHook flushes core and exits the process once the entry has been written.
+type exitHook struct {
+	core zapcore.Core
+	code int
+}
+
+func (h exitHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {
+	_ = h.core.Sync()
+	os.Exit(h.code)
+}
@@ -0,0 +1,93 @@
+package trace
+
+import (
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// errorReplacer flattens multi-line messages (such as errors.Join results or
+// embedded stack traces) so an error always encodes on a single line.
+var errorReplacer = strings.NewReplacer("\r\n", " | ", "\n", " | ", "\t", " ")
+
+// formatError returns err's message on a single line, joining lines with " | ".
+// A nil err, including a typed nil pointer, is rendered as "<nil>".
+func formatError(err error) string {
+	if isNil(err) {
+		return "<nil>"
+	}
+	return errorReplacer.Replace(err.Error())
+}
+
+// FieldError wraps an error together with fields that describe it. When the error,
+// or any error wrapping it, is logged with Err, the fields are added to the entry,
+// so context attached where the error was produced surfaces wherever it is logged.
+type FieldError struct {
+	Err    error
+	Fields []zap.Field
+}
+
+// ErrorWithFields wraps err in a FieldError carrying fields. It returns nil for a nil err.
+func ErrorWithFields(err error, fields ...zap.Field) error {
+	if err == nil {
+		return nil
+	}
+	return &FieldError{Err: err, Fields: fields}
+}
+
+func (e *FieldError) Error() string {
+	if isNil(e.Err) {
+		return "<nil>"
+	}
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// Err creates an "error" field holding err's message on a single line, followed by the
+// fields of every FieldError in its chain. A nil err produces an empty field, and a
+// typed nil pointer logs "<nil>".
+func Err(err error) zap.Field {
+	if err == nil {
+		return zap.Skip()
+	}
+	return zap.Inline(errorFields{err: err})
+}
+
+// ErrVerbose is like Err but keeps the causes of a multi-error apart: when err is an
+// errors.Join result, or otherwise implements Unwrap() []error, it creates an
+// "errors" field with one single-line entry per underlying error. Any other error
+// is logged as by Err, and a nil err produces an empty "errors" list.
+func ErrVerbose(err error) zap.Field {
+	if isNil(err) {
+		return zap.Strings("errors", []string{})
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return Err(err)
+	}
+	errs := joined.Unwrap()
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		msgs = append(msgs, formatError(e))
+	}
+	return zap.Strings("errors", msgs)
+}
+
+type errorFields struct {
+	err error
+}
+
+func (e errorFields) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("error", formatError(e.err))
+
+	var fe *FieldError
+	for next := e.err; errors.As(next, &fe); next = fe.Err {
+		for _, f := range fe.Fields {
+			f.AddTo(enc)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package trace
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestErrVerbose(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want map[string]interface{}
+	}{
+		{
+			name: "joined",
+			err:  errors.Join(errors.New("dial failed"), errors.New("retry\nexhausted"), errors.New("closed")),
+			want: map[string]interface{}{"errors": []interface{}{"dial failed", "retry | exhausted", "closed"}},
+		},
+		{
+			name: "nil",
+			err:  nil,
+			want: map[string]interface{}{"errors": []interface{}{}},
+		},
+		{
+			name: "single",
+			err:  errors.New("boom"),
+			want: map[string]interface{}{"error": "boom"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FieldsToMap(ErrVerbose(tt.err))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ErrVerbose(%v) = %#v, want %#v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
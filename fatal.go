@@ -0,0 +1,60 @@
+package trace
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// FatalBehavior decides what Fatal does after logging.
+type FatalBehavior int32
+
+const (
+	// ExitOnFatal is the default: Fatal logs and then exits with status 1.
+	ExitOnFatal FatalBehavior = iota
+	// LogOnly makes Fatal log at Fatal level and return, for tests and embedded
+	// uses where shared code must never end the process.
+	LogOnly
+)
+
+// fatalBehavior is the behaviour set by SetFatalBehavior, and fatalExit the hook
+// set by SetFatalHook.
+var (
+	fatalBehavior atomic.Int32
+	fatalExit     atomic.Pointer[func()]
+)
+
+// SetFatalBehavior sets what Fatal does on every logger built by this package.
+func SetFatalBehavior(b FatalBehavior) {
+	fatalBehavior.Store(int32(b))
+}
+
+// SetFatalHook makes Fatal call hook instead of exiting with status 1 once the
+// entry has been written, so tests can exercise shutdown paths that run on Fatal:
+//
+//	trace.SetFatalHook(func() { panic(errFatal) })
+//	defer trace.SetFatalHook(nil)
+//
+// If hook returns, so does Fatal. It has no effect under LogOnly. Passing nil
+// restores the default exit.
+func SetFatalHook(hook func()) {
+	if hook == nil {
+		fatalExit.Store(nil)
+		return
+	}
+	fatalExit.Store(&hook)
+}
+
+// fatalHook runs after a Fatal entry has been written.
+type fatalHook struct{}
+
+func (fatalHook) OnWrite(ce *zapcore.CheckedEntry, fields []zapcore.Field) {
+	if FatalBehavior(fatalBehavior.Load()) == LogOnly {
+		return
+	}
+	if hook := fatalExit.Load(); hook != nil {
+		(*hook)()
+		return
+	}
+	zapcore.WriteThenFatal.OnWrite(ce, fields)
+}
@@ -0,0 +1,84 @@
+package trace
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSetFatalHook(t *testing.T) {
+	defer Reset()
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := NewWithCore(core, "")
+
+	var ran bool
+	SetFatalHook(func() { ran = true })
+	logger.Fatal("shutting down", Str("reason", "test"))
+
+	if !ran {
+		t.Error("fatal hook did not run")
+	}
+	entries := logs.AllUntimed()
+	if len(entries) != 1 || entries[0].Level != zapcore.FatalLevel || entries[0].Message != "shutting down" {
+		t.Fatalf("entries = %v, want the fatal entry", entries)
+	}
+}
+
+func TestSetFatalHookPanic(t *testing.T) {
+	defer Reset()
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := NewWithCore(core, "")
+	errFatal := errors.New("fatal")
+	SetFatalHook(func() { panic(errFatal) })
+
+	func() {
+		defer func() {
+			if p := recover(); p != errFatal {
+				t.Errorf("recovered %v, want the hook's panic", p)
+			}
+		}()
+		logger.Fatal("unrecoverable")
+	}()
+	if got := messages(logs); !slices.Equal(got, []string{"unrecoverable"}) {
+		t.Errorf("got %q, want the entry written before the hook ran", got)
+	}
+}
+
+func TestFatalLogOnly(t *testing.T) {
+	defer Reset()
+	core, logs := observer.New(zapcore.DebugLevel)
+	SetFatalBehavior(LogOnly)
+	var ran bool
+	SetFatalHook(func() { ran = true })
+
+	NewWithCore(core, "").Fatal("logged only")
+
+	if ran || logs.Len() != 1 {
+		t.Errorf("hook ran = %t with %d entries, want no hook and one entry", ran, logs.Len())
+	}
+}
+
+// TestFatalExitsByDefault runs itself in a subprocess, which Fatal ends.
+func TestFatalExitsByDefault(t *testing.T) {
+	if os.Getenv("TRACE_TEST_FATAL") == "1" {
+		New(zapcore.InfoLevel, "", nil).Fatal("exiting")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFatalExitsByDefault$")
+	cmd.Env = append(os.Environ(), "TRACE_TEST_FATAL=1")
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("subprocess err = %v, want exit status 1", err)
+	}
+	if !strings.Contains(string(out), "exiting") {
+		t.Errorf("output = %q, want the fatal entry", out)
+	}
+}
@@ -0,0 +1,47 @@
+package trace
+
+import (
+	"sort"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithFieldOrder emits fields in a canonical order instead of call order:
+// the pinned keys first, in the order given, followed by every other field
+// sorted alphabetically by key. Timestamp, level, logger name and message are
+// part of the entry itself and always precede the fields.
+// Fields bound via With take part in the ordering too, so they are re-encoded per entry.
+// Fields following a zap.Namespace keep their call order so nesting is preserved.
+func WithFieldOrder(pinned ...string) Option {
+	rank := make(map[string]int, len(pinned))
+	for i, key := range pinned {
+		if _, ok := rank[key]; !ok {
+			rank[key] = i
+		}
+	}
+
+	return wrapCore(func(core zapcore.Core) zapcore.Core {
+		return newRewriteCore(core, func(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field, bool) {
+			head := fields
+			for i, f := range fields {
+				if f.Type == zapcore.NamespaceType {
+					head = fields[:i]
+					break
+				}
+			}
+			sort.SliceStable(head, func(i, j int) bool {
+				ri, iPinned := rank[head[i].Key]
+				rj, jPinned := rank[head[j].Key]
+				switch {
+				case iPinned && jPinned:
+					return ri < rj
+				case iPinned != jPinned:
+					return iPinned
+				default:
+					return head[i].Key < head[j].Key
+				}
+			})
+			return ent, fields, true
+		})
+	})
+}
@@ -0,0 +1,119 @@
+package trace
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// FieldEncoder renders a value of a registered type as a field.
+type FieldEncoder func(key string, v interface{}) zap.Field
+
+var (
+	fieldEncodersMu sync.Mutex
+	fieldEncoders   atomic.Pointer[map[reflect.Type]FieldEncoder] // copy-on-write
+)
+
+// RegisterFieldEncoder makes Any render values of type t with enc, centralizing
+// how domain types (money, emails, phone numbers) are logged and redacted.
+// A pointer to a registered type is dereferenced before encoding.
+// Passing a nil enc removes the registration.
+func RegisterFieldEncoder(t reflect.Type, enc FieldEncoder) {
+	if t == nil {
+		return
+	}
+
+	fieldEncodersMu.Lock()
+	defer fieldEncodersMu.Unlock()
+
+	next := make(map[reflect.Type]FieldEncoder)
+	if cur := fieldEncoders.Load(); cur != nil {
+		for k, v := range *cur {
+			next[k] = v
+		}
+	}
+	if enc == nil {
+		delete(next, t)
+	} else {
+		next[t] = enc
+	}
+	fieldEncoders.Store(&next)
+}
+
+func resetFieldEncoders() {
+	fieldEncodersMu.Lock()
+	fieldEncoders.Store(nil)
+	fieldEncodersMu.Unlock()
+}
+
+// Any creates a field for an arbitrary value, using a registered FieldEncoder
+// for its type when there is one and zap.Any otherwise. Nil pointers never reach
+// a FieldEncoder; they are logged by zap.Any as null.
+func Any(key string, val interface{}) zap.Field {
+	if encoders := fieldEncoders.Load(); encoders != nil && !isNil(val) {
+		t := reflect.TypeOf(val)
+		if enc, ok := (*encoders)[t]; ok {
+			return enc(key, val)
+		}
+		if t.Kind() == reflect.Pointer {
+			if enc, ok := (*encoders)[t.Elem()]; ok {
+				return enc(key, reflect.ValueOf(val).Elem().Interface())
+			}
+		}
+	}
+	return zap.Any(key, val)
+}
+
+// Typed field helpers. They are drop-in equivalents of the zap constructors of the
+// same kind, so call sites need to import only this package.
+
+// Str creates a string field.
+func Str(key, val string) zap.Field { return zap.String(key, val) }
+
+// Int creates an int field.
+func Int(key string, val int) zap.Field { return zap.Int(key, val) }
+
+// Bool creates a bool field.
+func Bool(key string, val bool) zap.Field { return zap.Bool(key, val) }
+
+// Float64 creates a float64 field.
+func Float64(key string, val float64) zap.Field { return zap.Float64(key, val) }
+
+// Dur creates a time.Duration field.
+func Dur(key string, val time.Duration) zap.Field { return zap.Duration(key, val) }
+
+// Time creates a time.Time field.
+func Time(key string, val time.Time) zap.Field { return zap.Time(key, val) }
+
+// Strings creates a field holding a list of strings.
+func Strings(key string, vals []string) zap.Field { return zap.Strings(key, vals) }
+
+// FieldsToMap encodes fields the way a JSON logger would and returns the result,
+// so tests and hooks can inspect field values without parsing output. Objects and
+// namespaces become nested maps, arrays become slices, and durations and times keep
+// their Go types. Later fields with the same key overwrite earlier ones.
+func FieldsToMap(fields ...zap.Field) map[string]interface{} {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return enc.Fields
+}
+
+// isNil reports whether v is nil or a nil pointer, map, slice, func, chan or
+// interface inside a non-nil interface. Field helpers use it so that logging a
+// nil input yields "<nil>" or an empty value and never panics.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
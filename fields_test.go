@@ -0,0 +1,111 @@
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ptrErr and ptrStringer dereference their receiver, so a typed nil one panics if called.
+type ptrErr struct{ msg string }
+
+func (e *ptrErr) Error() string { return e.msg }
+
+type ptrStringer struct{ name string }
+
+func (s *ptrStringer) String() string { return s.name }
+
+type registered struct{ id int }
+
+// encodeJSON encodes fields as a JSON entry and decodes the result, so lazily
+// encoded values such as Stringers and object marshalers are exercised.
+func encodeJSON(t *testing.T, fields ...zap.Field) map[string]interface{} {
+	t.Helper()
+	buf, err := zapcore.NewJSONEncoder(zapcore.EncoderConfig{}).EncodeEntry(zapcore.Entry{}, fields)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	defer buf.Free()
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+	}
+	return m
+}
+
+func TestNilInputs(t *testing.T) {
+	defer Reset()
+	RegisterFieldEncoder(reflect.TypeOf(registered{}), func(key string, val interface{}) zap.Field {
+		return zap.Int(key, val.(registered).id)
+	})
+
+	tests := []struct {
+		name   string
+		fields []zap.Field
+		want   map[string]interface{} // nil to only check that encoding succeeds
+	}{
+		{"Err nil", []zap.Field{Err(nil)}, map[string]interface{}{}},
+		{"Err typed nil", []zap.Field{Err((*ptrErr)(nil))}, map[string]interface{}{"error": "<nil>"}},
+		{"Err FieldError nil cause", []zap.Field{Err(&FieldError{})}, map[string]interface{}{"error": "<nil>"}},
+		{"ErrVerbose typed nil", []zap.Field{ErrVerbose((*ptrErr)(nil))}, nil},
+		{"Enum nil", []zap.Field{Enum("state", nil)}, map[string]interface{}{"state": "<nil>"}},
+		{"Enum typed nil", []zap.Field{Enum("state", (*ptrStringer)(nil))}, map[string]interface{}{"state": "<nil>"}},
+		{"Any nil", []zap.Field{Any("v", nil)}, map[string]interface{}{"v": nil}},
+		{"Any nil registered pointer", []zap.Field{Any("v", (*registered)(nil))}, map[string]interface{}{"v": nil}},
+		{"RawJSON empty", []zap.Field{RawJSON("body", nil)}, map[string]interface{}{"body": nil}},
+		{"Hashed nil", []zap.Field{Hashed("body", nil)}, nil},
+		{"Strings nil", []zap.Field{Strings("tags", nil)}, nil},
+		{"StrsCapped nil", []zap.Field{StrsCapped("tags", nil, 3)}, nil},
+		{"IntsCapped nil", []zap.Field{IntsCapped("ids", nil, 3)}, nil},
+		{"SortedMap nil", []zap.Field{SortedMap("m", nil)}, nil},
+		{"SortedStringMap nil", []zap.Field{SortedStringMap("m", nil)}, nil},
+		{"SortedIntMap nil", []zap.Field{SortedIntMap("m", nil)}, nil},
+		{"Flags nil", []zap.Field{Flags(nil)}, nil},
+		{"ConfigDump nil", []zap.Field{ConfigDump("cfg", nil)}, nil},
+		{"Diff nil", []zap.Field{Diff("d", nil, nil)}, nil},
+		{"Mismatch nil", []zap.Field{Mismatch("m", nil, nil)}, nil},
+		{"Claims nil scopes", Claims("user", nil, "issuer"), nil},
+		{"CtxFields nil ctx", CtxFields(nil, "request_id"), map[string]interface{}{}},
+		{"TenantField nil ctx", []zap.Field{TenantField(nil)}, map[string]interface{}{}},
+		{"TenantField empty ctx", []zap.Field{TenantField(context.Background())}, map[string]interface{}{}},
+		{"RetryAttempt nil err", RetryAttempt(1, 3, nil, 0), nil},
+		{"ConfigReload nil changed", ConfigReload("file", nil), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := encodeJSON(t, tt.fields...)
+			if tt.want != nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypedFieldHelpers(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		got, want zap.Field
+	}{
+		{"Str", Str("k", "v"), zap.String("k", "v")},
+		{"Int", Int("k", 42), zap.Int("k", 42)},
+		{"Bool", Bool("k", true), zap.Bool("k", true)},
+		{"Float64", Float64("k", 1.5), zap.Float64("k", 1.5)},
+		{"Dur", Dur("k", time.Second), zap.Duration("k", time.Second)},
+		{"Time", Time("k", now), zap.Time("k", now)},
+		{"Strings", Strings("k", []string{"a", "b"}), zap.Strings("k", []string{"a", "b"})},
+		{"Any", Any("k", map[string]int{"a": 1}), zap.Any("k", map[string]int{"a": 1})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equals(tt.want) {
+				t.Errorf("%s = %#v, want %#v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package trace
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithFilter drops every entry for which keep returns false, for silencing known-noisy
+// messages such as /healthz access logs without touching call sites:
+//
+//	trace.WithFilter(func(ent zapcore.Entry, fields []zap.Field) bool {
+//		for _, f := range fields {
+//			if f.Key == "path" && f.String == "/healthz" {
+//				return false
+//			}
+//		}
+//		return true
+//	})
+//
+// keep sees the fields bound via With as well as those of the call, so bound fields
+// are re-encoded per entry. It runs only for entries enabled by level and must be
+// safe for concurrent use. A nil keep filters nothing.
+func WithFilter(keep func(ent zapcore.Entry, fields []zap.Field) bool) Option {
+	if keep == nil {
+		return nil
+	}
+	return wrapCore(func(core zapcore.Core) zapcore.Core {
+		return newRewriteCore(core, func(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field, bool) {
+			return ent, fields, keep(ent, fields)
+		})
+	})
+}
@@ -0,0 +1,18 @@
+package trace
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Flags logs the feature-flag state of a request as a "flags" object mapping each
+// flag name to whether it is enabled, in sorted order. Bind it once per request so
+// every line carries the flag context:
+//
+//	reqLogger := logger.With(trace.Flags(activeFlags))
+func Flags(flags map[string]bool) zap.Field {
+	return zap.Object("flags", sortedMap[bool]{m: flags, add: func(enc zapcore.ObjectEncoder, k string, v bool) error {
+		enc.AddBool(k, v)
+		return nil
+	}})
+}
@@ -0,0 +1,187 @@
+package trace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/term"
+)
+
+// Output formats accepted by SetDefaultFormat.
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+// SetDefaultFormat switches the default logger between human-readable console output
+// and structured JSON at runtime, keeping its level, sinks and bound fields. The
+// switch is atomic: every entry is written entirely in one format. It applies to every
+// logger derived from the same root, and fails if the default logger was not built by
+// New, NewJSON, NewWithSink or NewWithPath.
+func SetDefaultFormat(format string) error {
+	var json bool
+	switch format {
+	case FormatConsole:
+	case FormatJSON:
+		json = true
+	default:
+		return fmt.Errorf("trace: unknown format %q", format)
+	}
+
+	l, ok := GetDefaultLogger().(*sugarLogger)
+	if !ok || l.cfg == nil || l.cfg.json == nil {
+		return errors.New("trace: default logger does not support switching formats")
+	}
+	l.cfg.json.Store(json)
+	return nil
+}
+
+// newJSONEncoderConfig returns the encoder config used for JSON output: the same keys
+// as the console format, with plain level names and ISO8601 timestamps.
+func newJSONEncoderConfig() zapcore.EncoderConfig {
+	cfg := newEncoderConfig()
+	cfg.EncodeLevel = zapcore.LowercaseLevelEncoder
+	cfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	return cfg
+}
+
+// WithMessageNewlines replaces line breaks in entry messages with replacement while
+// the logger writes JSON, so that a message carrying a stack trace or other
+// multi-line text stays on one visual line, as formatError already does for errors.
+// An empty replacement joins lines with " | ". Console output is left untouched.
+func WithMessageNewlines(replacement string) Option {
+	if replacement == "" {
+		replacement = " | "
+	}
+	return func(o *options) {
+		o.msgReplacer = strings.NewReplacer("\r\n", replacement, "\n", replacement, "\r", replacement)
+	}
+}
+
+// Layouts for WithTimeFormat besides those of package time. TimeFormatEpochNanos
+// writes timestamps as integer nanoseconds since the Unix epoch.
+const (
+	TimeFormatRFC3339     = time.RFC3339
+	TimeFormatRFC3339Nano = time.RFC3339Nano
+	TimeFormatEpochNanos  = "epoch_nanos"
+)
+
+// WithTimeFormat sets the layout of entry timestamps, as accepted by time.Format,
+// for both console and JSON output of loggers built by New, NewJSON,
+// NewWithOptions, NewWithSink and NewWithPath. By default console output uses
+// "2006-01-02 15:04:05" and JSON output ISO8601, both in local time.
+func WithTimeFormat(layout string) Option {
+	return func(o *options) {
+		o.timeFormat = layout
+	}
+}
+
+// WithUTC writes entry timestamps in UTC rather than local time, whatever their layout.
+func WithUTC(enabled bool) Option {
+	return func(o *options) {
+		o.utc = enabled
+	}
+}
+
+// encodeTime returns the timestamp encoder to use instead of def, the default of
+// the output format.
+func (o *options) encodeTime(def zapcore.TimeEncoder) zapcore.TimeEncoder {
+	enc := def
+	switch o.timeFormat {
+	case "":
+	case TimeFormatEpochNanos:
+		enc = zapcore.EpochNanosTimeEncoder
+	default:
+		enc = zapcore.TimeEncoderOfLayout(o.timeFormat)
+	}
+	if !o.utc {
+		return enc
+	}
+	return func(t time.Time, pae zapcore.PrimitiveArrayEncoder) {
+		enc(t.UTC(), pae)
+	}
+}
+
+// WithColor forces colored level names in console output to stdout on or off. By
+// default they are used only when stdout is a terminal, so that redirected output
+// is free of ANSI escape codes. Files and other sinks never get color.
+func WithColor(enabled bool) Option {
+	return func(o *options) {
+		o.color = &enabled
+	}
+}
+
+// stdoutColor reports whether console output to stdout uses colored levels.
+func (o *options) stdoutColor() bool {
+	if o.color != nil {
+		return *o.color
+	}
+	return isTerminal(os.Stdout)
+}
+
+// isTerminal reports whether f is a terminal. Other character devices, such as
+// /dev/null, are not.
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// formatCore writes to one sink through either a console or a JSON core, chosen per
+// entry by a flag shared across the logger tree. Fields bound via With are added to
+// both so that switching never loses context. When msg is set, it rewrites the
+// message of every entry written as JSON.
+type formatCore struct {
+	console, json zapcore.Core
+	useJSON       *atomic.Bool
+	msg           *strings.Replacer
+}
+
+func newFormatCore(ws zapcore.WriteSyncer, enab zapcore.LevelEnabler, useJSON *atomic.Bool, o *options, color bool) zapcore.Core {
+	consoleCfg, jsonCfg := newEncoderConfig(), newJSONEncoderConfig()
+	if !color {
+		consoleCfg.EncodeLevel = zapcore.CapitalLevelEncoder
+	}
+	consoleCfg.EncodeTime = o.encodeTime(consoleCfg.EncodeTime)
+	jsonCfg.EncodeTime = o.encodeTime(jsonCfg.EncodeTime)
+	if o.caller {
+		for _, cfg := range []*zapcore.EncoderConfig{&consoleCfg, &jsonCfg} {
+			cfg.CallerKey = "caller"
+			cfg.EncodeCaller = zapcore.ShortCallerEncoder
+		}
+	}
+	return &formatCore{
+		console: zapcore.NewCore(zapcore.NewConsoleEncoder(consoleCfg), ws, enab),
+		json:    zapcore.NewCore(zapcore.NewJSONEncoder(jsonCfg), ws, enab),
+		useJSON: useJSON,
+		msg:     o.msgReplacer,
+	}
+}
+
+func (c *formatCore) Enabled(level zapcore.Level) bool { return c.console.Enabled(level) }
+
+func (c *formatCore) With(fields []zapcore.Field) zapcore.Core {
+	return &formatCore{console: c.console.With(fields), json: c.json.With(fields), useJSON: c.useJSON, msg: c.msg}
+}
+
+func (c *formatCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *formatCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if !c.useJSON.Load() {
+		return c.console.Write(ent, fields)
+	}
+	if c.msg != nil {
+		ent.Message = c.msg.Replace(ent.Message)
+	}
+	return c.json.Write(ent, fields)
+}
+
+func (c *formatCore) Sync() error { return c.console.Sync() }
@@ -0,0 +1,66 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestStdoutNoColorWhenPiped(t *testing.T) {
+	out := captureStdout(t, func() {
+		logger := New(zapcore.InfoLevel, "", nil)
+		logger.Info("piped output")
+		_ = logger.Sync()
+	})
+	if !strings.Contains(out, "piped output") {
+		t.Fatalf("output missing entry:\n%s", out)
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("piped output contains ANSI escapes: %q", out)
+	}
+}
+
+func TestIsTerminalDevNull(t *testing.T) {
+	f, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if isTerminal(f) {
+		t.Errorf("isTerminal(%s) = true, want false", os.DevNull)
+	}
+}
+
+func TestWithTimeFormatUTC(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	now := time.Date(2024, 5, 1, 14, 30, 0, 0, loc)
+
+	var buf bytes.Buffer
+	logger := NewWithSink(zapcore.InfoLevel, "", zapcore.AddSync(&buf),
+		WithJSON(true), WithTimeFormat(TimeFormatRFC3339), WithUTC(true),
+		WithClock(func() time.Time { return now }))
+	logger.Info("timestamped")
+
+	var entry struct {
+		TS string `json:"ts"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unmarshal %q: %v", buf.String(), err)
+	}
+	if !strings.HasSuffix(entry.TS, "Z") {
+		t.Errorf("ts = %q, want UTC ending in Z", entry.TS)
+	}
+	ts, err := time.Parse(time.RFC3339, entry.TS)
+	if err != nil {
+		t.Fatalf("ts %q is not RFC3339: %v", entry.TS, err)
+	}
+	if !ts.Equal(now) {
+		t.Errorf("ts = %v, want %v", ts, now)
+	}
+}
@@ -0,0 +1,107 @@
+// Package gelf writes trace logs in the Graylog Extended Log Format (GELF 1.1), so
+// entries can be sent to Graylog directly instead of through a converting agent.
+//
+//	w, err := gelf.Dial("udp", "graylog:12201")
+//	logger := gelf.New(zapcore.InfoLevel, "app", w)
+//
+// Entry fields become additional fields, prefixed with "_" as GELF requires.
+// Namespaces are flattened into field names; fields of nested objects keep their keys.
+package gelf
+
+import (
+	"os"
+	"time"
+
+	"github.com/broaskaGit/trace"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// Version is the GELF version written to every message.
+const Version = "1.1"
+
+// New returns a logger writing GELF messages at or above level to w, such as a
+// writer returned by Dial.
+func New(level zapcore.Level, prefix string, w zapcore.WriteSyncer, opts ...trace.Option) trace.Logger {
+	return trace.NewWithCore(NewCore(level, w), prefix, opts...)
+}
+
+// NewCore returns a core encoding entries at or above enab as GELF messages for w.
+// The host field is the machine's hostname.
+func NewCore(enab zapcore.LevelEnabler, w zapcore.WriteSyncer) zapcore.Core {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return zapcore.NewCore(NewEncoder(host), zapcore.Lock(w), enab)
+}
+
+var _ zapcore.Encoder = &Encoder{}
+
+// Encoder is a zapcore.Encoder producing one GELF JSON object per entry.
+type Encoder struct {
+	json zapcore.Encoder // holds the additional fields, keys already prefixed
+	host string
+	ns   string // open namespaces, joined and dot-terminated
+}
+
+// NewEncoder returns a GELF encoder reporting host as the message source.
+func NewEncoder(host string) *Encoder {
+	return &Encoder{json: zapcore.NewJSONEncoder(zapcore.EncoderConfig{}), host: host}
+}
+
+// Clone copies the encoder, including the fields added to it.
+func (e *Encoder) Clone() zapcore.Encoder {
+	return &Encoder{json: e.json.Clone(), host: e.host, ns: e.ns}
+}
+
+// EncodeEntry writes ent and fields as a GELF message terminated by a newline.
+func (e *Encoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	msg := e.json.Clone()
+	prefixed := &Encoder{json: msg, host: e.host, ns: e.ns}
+	for _, f := range fields {
+		f.AddTo(prefixed)
+	}
+
+	msg.AddString("version", Version)
+	msg.AddString("host", e.host)
+	msg.AddString("short_message", ent.Message)
+	if ent.Stack != "" {
+		msg.AddString("full_message", ent.Message+"\n"+ent.Stack)
+	}
+	msg.AddFloat64("timestamp", float64(ent.Time.UnixNano())/float64(time.Second))
+	msg.AddInt("level", severity(ent.Level))
+	if ent.LoggerName != "" {
+		msg.AddString("_logger", ent.LoggerName)
+	}
+	if ent.Caller.Defined {
+		msg.AddString("_file", ent.Caller.File)
+		msg.AddInt("_line", ent.Caller.Line)
+	}
+	return msg.EncodeEntry(zapcore.Entry{}, nil)
+}
+
+// severity maps zap levels to syslog severities.
+func severity(l zapcore.Level) int {
+	switch {
+	case l <= zapcore.DebugLevel:
+		return 7
+	case l == zapcore.InfoLevel:
+		return 6
+	case l == zapcore.WarnLevel:
+		return 4
+	case l == zapcore.ErrorLevel:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// key turns a field key into an additional-field name. GELF reserves "_id".
+func (e *Encoder) key(k string) string {
+	k = e.ns + k
+	if k == "id" {
+		return "_id_"
+	}
+	return "_" + k
+}
@@ -0,0 +1,48 @@
+package gelf
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// The ObjectEncoder methods add top-level fields under their GELF names. Nested
+// values are handed the underlying JSON encoder, so their keys stay unprefixed.
+
+func (e *Encoder) AddArray(k string, v zapcore.ArrayMarshaler) error {
+	return e.json.AddArray(e.key(k), v)
+}
+
+func (e *Encoder) AddObject(k string, v zapcore.ObjectMarshaler) error {
+	return e.json.AddObject(e.key(k), v)
+}
+
+func (e *Encoder) AddBinary(k string, v []byte)          { e.json.AddBinary(e.key(k), v) }
+func (e *Encoder) AddByteString(k string, v []byte)      { e.json.AddByteString(e.key(k), v) }
+func (e *Encoder) AddBool(k string, v bool)              { e.json.AddBool(e.key(k), v) }
+func (e *Encoder) AddComplex128(k string, v complex128)  { e.json.AddComplex128(e.key(k), v) }
+func (e *Encoder) AddComplex64(k string, v complex64)    { e.json.AddComplex64(e.key(k), v) }
+func (e *Encoder) AddDuration(k string, v time.Duration) { e.json.AddDuration(e.key(k), v) }
+func (e *Encoder) AddFloat64(k string, v float64)        { e.json.AddFloat64(e.key(k), v) }
+func (e *Encoder) AddFloat32(k string, v float32)        { e.json.AddFloat32(e.key(k), v) }
+func (e *Encoder) AddInt(k string, v int)                { e.json.AddInt(e.key(k), v) }
+func (e *Encoder) AddInt64(k string, v int64)            { e.json.AddInt64(e.key(k), v) }
+func (e *Encoder) AddInt32(k string, v int32)            { e.json.AddInt32(e.key(k), v) }
+func (e *Encoder) AddInt16(k string, v int16)            { e.json.AddInt16(e.key(k), v) }
+func (e *Encoder) AddInt8(k string, v int8)              { e.json.AddInt8(e.key(k), v) }
+func (e *Encoder) AddString(k, v string)                 { e.json.AddString(e.key(k), v) }
+func (e *Encoder) AddTime(k string, v time.Time)         { e.json.AddTime(e.key(k), v) }
+func (e *Encoder) AddUint(k string, v uint)              { e.json.AddUint(e.key(k), v) }
+func (e *Encoder) AddUint64(k string, v uint64)          { e.json.AddUint64(e.key(k), v) }
+func (e *Encoder) AddUint32(k string, v uint32)          { e.json.AddUint32(e.key(k), v) }
+func (e *Encoder) AddUint16(k string, v uint16)          { e.json.AddUint16(e.key(k), v) }
+func (e *Encoder) AddUint8(k string, v uint8)            { e.json.AddUint8(e.key(k), v) }
+func (e *Encoder) AddUintptr(k string, v uintptr)        { e.json.AddUintptr(e.key(k), v) }
+
+func (e *Encoder) AddReflected(k string, v interface{}) error {
+	return e.json.AddReflected(e.key(k), v)
+}
+
+// OpenNamespace flattens the namespace into the names of subsequent fields, since
+// GELF additional fields cannot nest: table in namespace db becomes "_db.table".
+func (e *Encoder) OpenNamespace(k string) { e.ns += k + "." }
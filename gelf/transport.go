@@ -0,0 +1,90 @@
+package gelf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// UDP chunking parameters from the GELF specification.
+const (
+	chunkSize  = 1420 // payload per datagram, safe for common MTUs
+	maxChunks  = 128
+	chunkMagic = "\x1e\x0f"
+)
+
+// Dial connects to a GELF input over "udp" or "tcp" and returns a WriteSyncer for
+// New or NewCore. Over UDP, messages larger than one datagram are chunked; over TCP
+// they are delimited by a null byte. Each Write must hold one encoded message.
+func Dial(network, addr string) (zapcore.WriteSyncer, error) {
+	switch network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6":
+	default:
+		return nil, fmt.Errorf("gelf: unsupported network %q", network)
+	}
+	conn, err := net.Dial(network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("gelf: %w", err)
+	}
+	if _, ok := conn.(*net.UDPConn); ok {
+		return &udpWriter{conn: conn}, nil
+	}
+	return &tcpWriter{conn: conn}, nil
+}
+
+// tcpWriter frames messages with a trailing null byte.
+type tcpWriter struct {
+	conn net.Conn
+}
+
+func (w *tcpWriter) Write(p []byte) (int, error) {
+	msg := append(bytes.TrimSuffix(p, []byte("\n")), 0)
+	if _, err := w.conn.Write(msg); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *tcpWriter) Sync() error { return nil }
+
+// udpWriter sends each message as one datagram, or as GELF chunks if it is too large.
+type udpWriter struct {
+	conn net.Conn
+}
+
+func (w *udpWriter) Write(p []byte) (int, error) {
+	msg := bytes.TrimSuffix(p, []byte("\n"))
+	if len(msg) <= chunkSize {
+		if _, err := w.conn.Write(msg); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	count := (len(msg) + chunkSize - 1) / chunkSize
+	if count > maxChunks {
+		return 0, errors.New("gelf: message too large for UDP")
+	}
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return 0, err
+	}
+	chunk := make([]byte, 0, 12+chunkSize)
+	for i := 0; i < count; i++ {
+		end := min((i+1)*chunkSize, len(msg))
+		chunk = append(chunk[:0], chunkMagic...)
+		chunk = append(chunk, id[:]...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, msg[i*chunkSize:end]...)
+		if _, err := w.conn.Write(chunk); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (w *udpWriter) Sync() error { return nil }
@@ -0,0 +1,41 @@
+package trace
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TrackGoroutine logs at Debug that the calling goroutine started and returns a func,
+// to be deferred, that logs its end with the elapsed lifetime. Both entries carry the
+// goroutine name and its runtime ID (goroutine_id), which matches the IDs in stack
+// dumps and leak reports:
+//
+//	go func() {
+//		defer trace.TrackGoroutine(logger, "poller")()
+//		...
+//	}()
+func TrackGoroutine(logger Logger, name string) func() {
+	start := time.Now()
+	fields := []zap.Field{zap.String("goroutine", name), zap.Uint64("goroutine_id", goroutineID())}
+	logger.Debug("goroutine started", fields...)
+	return func() {
+		logger.Debug("goroutine ended", append(fields, zap.Duration("lifetime", time.Since(start)))...)
+	}
+}
+
+// goroutineID parses the current goroutine's ID from the "goroutine N [...]" stack
+// header. It returns 0 if the header cannot be parsed.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
@@ -0,0 +1,34 @@
+package trace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Hashed logs a fingerprint of val instead of val itself: key_hash holds the first
+// 8 hex digits of its SHA-256 and key_len its length in bytes. Two entries with the
+// same fingerprint almost certainly refer to the same payload, without logging
+// sensitive or bulky content. The hash is computed only when the entry is encoded.
+func Hashed(key string, val []byte) zap.Field {
+	return zap.Inline(hashedValue{key: key, val: val})
+}
+
+type hashedValue struct {
+	key string
+	val []byte
+}
+
+func (h hashedValue) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString(h.key+"_hash", shortHash(h.val))
+	enc.AddInt(h.key+"_len", len(h.val))
+	return nil
+}
+
+// shortHash returns the first 8 hex digits of the SHA-256 of b.
+func shortHash(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:4])
+}
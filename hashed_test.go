@@ -0,0 +1,32 @@
+package trace
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHashed(t *testing.T) {
+	got := FieldsToMap(Hashed("body", []byte("hello")))
+	want := map[string]interface{}{"body_hash": "2cf24dba", "body_len": 5}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Hashed = %v, want %v", got, want)
+	}
+}
+
+func TestHashedDeterministic(t *testing.T) {
+	payload := []byte(`{"user":"alice","items":[1,2,3]}`)
+	first := FieldsToMap(Hashed("payload", payload))
+	for i := 0; i < 3; i++ {
+		if got := FieldsToMap(Hashed("payload", append([]byte(nil), payload...))); !reflect.DeepEqual(got, first) {
+			t.Fatalf("Hashed of the same payload = %v, then %v", first, got)
+		}
+	}
+
+	other := FieldsToMap(Hashed("payload", []byte(`{"user":"bob","items":[1,2,3]}`)))
+	if other["payload_hash"] == first["payload_hash"] {
+		t.Errorf("different payloads share hash %v", first["payload_hash"])
+	}
+	if empty := FieldsToMap(Hashed("payload", nil)); empty["payload_len"] != 0 {
+		t.Errorf("Hashed(nil) = %v, want length 0", empty)
+	}
+}
@@ -1,6 +1,13 @@
 package trace
 
-import "go.uber.org/zap"
+import (
+	"log/slog"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
 
 // Logger defines the logging methods
 type Logger interface {
@@ -9,10 +16,50 @@ type Logger interface {
 	Warn(msg string, fields ...zap.Field)
 	Error(msg string, fields ...zap.Field)
 	Fatal(msg string, fields ...zap.Field)
+	// Debugf, Infof, Warnf, Errorf and Fatalf log a message formatted with fmt.Sprintf.
+	Debugf(template string, args ...interface{})
+	Infof(template string, args ...interface{})
+	Warnf(template string, args ...interface{})
+	Errorf(template string, args ...interface{})
+	Fatalf(template string, args ...interface{})
+	// Log logs a message at a level chosen at runtime.
+	Log(level zapcore.Level, msg string, fields ...zap.Field)
+	// LogAt logs a message at level with the entry timestamp set to t.
+	LogAt(t time.Time, level zapcore.Level, msg string, fields ...zap.Field)
+	// WarnOnce logs a warning only the first time key is seen by the logger.
+	WarnOnce(key, msg string, fields ...zap.Field)
 	// With returns a child logger with additional structured fields included in every log.
 	With(fields ...zap.Field) Logger
+	// Group returns a child logger that nests all subsequent fields under name.
+	Group(name string) Logger
+	// WithTTL returns a child logger that adds fields to its entries only until ttl has passed.
+	WithTTL(ttl time.Duration, fields ...zap.Field) Logger
+	// WithDynamic returns a child logger that re-evaluates fn for every entry it writes.
+	WithDynamic(key string, fn func() interface{}) Logger
 	// Named returns a child logger with a name scope (logger name prefix).
 	Named(name string) Logger
+	// Tagged returns a child logger that prepends "[tag] " to every message.
+	Tagged(tag string) Logger
+	// BeginOp returns a child logger whose entries carry the nested operation path in op_path.
+	BeginOp(name string) Logger
+	// SetLevel changes the minimum enabled level at runtime.
+	SetLevel(level zapcore.Level)
+	// GetLevel returns the current minimum enabled level.
+	GetLevel() zapcore.Level
+	// Silence mutes the logger and those derived from it until the returned restore func is called.
+	Silence() (restore func())
+	// ReopenOnSignal reopens the logger's own log file whenever sig is received.
+	ReopenOnSignal(sig os.Signal) (stop func())
+	// Rate returns the recent number of entries written per second.
+	Rate() (perSecond float64)
+	// LastError returns the most recent entry logged at Error or above.
+	LastError() (msg string, fields []zap.Field, at time.Time)
+	// Snapshot describes the logger's effective configuration for crash reports.
+	Snapshot() map[string]interface{}
+	// Sync flushes any buffered output of the logger's sinks.
+	Sync() error
+	// Slog returns a *slog.Logger that routes records through this logger.
+	Slog() *slog.Logger
 	// Zap returns the underlying zap.Logger.
 	Zap() *zap.Logger
 }
@@ -27,11 +74,44 @@ func NewNoopLogger() Logger {
 
 // NoopLogger implementation methods
 
-func (n *NoopLogger) Debug(msg string, fields ...zap.Field) {}
-func (n *NoopLogger) Info(msg string, fields ...zap.Field)  {}
-func (n *NoopLogger) Warn(msg string, fields ...zap.Field)  {}
-func (n *NoopLogger) Error(msg string, fields ...zap.Field) {}
-func (n *NoopLogger) Fatal(msg string, fields ...zap.Field) {}
-func (n *NoopLogger) With(fields ...zap.Field) Logger       { return n }
-func (n *NoopLogger) Named(name string) Logger              { return n }
-func (n *NoopLogger) Zap() *zap.Logger                      { return zap.NewNop() }
+func (n *NoopLogger) Debug(msg string, fields ...zap.Field)                    {}
+func (n *NoopLogger) Info(msg string, fields ...zap.Field)                     {}
+func (n *NoopLogger) Warn(msg string, fields ...zap.Field)                     {}
+func (n *NoopLogger) Error(msg string, fields ...zap.Field)                    {}
+func (n *NoopLogger) Fatal(msg string, fields ...zap.Field)                    {}
+func (n *NoopLogger) Debugf(template string, args ...interface{})              {}
+func (n *NoopLogger) Infof(template string, args ...interface{})               {}
+func (n *NoopLogger) Warnf(template string, args ...interface{})               {}
+func (n *NoopLogger) Errorf(template string, args ...interface{})              {}
+func (n *NoopLogger) Fatalf(template string, args ...interface{})              {}
+func (n *NoopLogger) Log(level zapcore.Level, msg string, fields ...zap.Field) {}
+func (n *NoopLogger) WarnOnce(key, msg string, fields ...zap.Field)            {}
+func (n *NoopLogger) With(fields ...zap.Field) Logger                          { return n }
+func (n *NoopLogger) Group(name string) Logger                                 { return n }
+func (n *NoopLogger) WithTTL(ttl time.Duration, fields ...zap.Field) Logger    { return n }
+func (n *NoopLogger) WithDynamic(key string, fn func() interface{}) Logger     { return n }
+func (n *NoopLogger) Named(name string) Logger                                 { return n }
+func (n *NoopLogger) Tagged(tag string) Logger                                 { return n }
+func (n *NoopLogger) BeginOp(name string) Logger                               { return n }
+func (n *NoopLogger) SetLevel(level zapcore.Level)                             {}
+func (n *NoopLogger) GetLevel() zapcore.Level                                  { return zapcore.InvalidLevel }
+func (n *NoopLogger) Silence() (restore func())                                { return func() {} }
+func (n *NoopLogger) ReopenOnSignal(sig os.Signal) (stop func())               { return func() {} }
+func (n *NoopLogger) Rate() (perSecond float64)                                { return 0 }
+func (n *NoopLogger) Sync() error                                              { return nil }
+func (n *NoopLogger) Slog() *slog.Logger                                       { return slog.New(slog.DiscardHandler) }
+func (n *NoopLogger) Zap() *zap.Logger                                         { return zap.NewNop() }
+
+func (n *NoopLogger) LogAt(t time.Time, level zapcore.Level, msg string, fields ...zap.Field) {
+}
+
+func (n *NoopLogger) LastError() (msg string, fields []zap.Field, at time.Time) {
+	return "", nil, time.Time{}
+}
+
+func (n *NoopLogger) Snapshot() map[string]interface{} {
+	return map[string]interface{}{"logger": "noop"}
+}
+
+// String implements fmt.Stringer.
+func (n *NoopLogger) String() string { return "NoopLogger{}" }
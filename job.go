@@ -0,0 +1,66 @@
+package trace
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// jobStartedKey is the key of the field set by JobStarted.
+const jobStartedKey = "job_started"
+
+// JobStarted records when a job started. Passed to JobProgress, it also enables
+// the job_eta estimate.
+func JobStarted(start time.Time) zap.Field {
+	return zap.Time(jobStartedKey, start)
+}
+
+// JobProgress returns the standard progress fields of a long-running job: job_id,
+// done, total and percent (capped at 100, and 0 for a non-positive total), followed
+// by fields. When fields include JobStarted, job_eta estimates the remaining time
+// from the average pace so far:
+//
+//	logger.Info("progress", trace.JobProgress(id, n, len(items), trace.JobStarted(start))...)
+func JobProgress(jobID string, done, total int, fields ...zap.Field) []zap.Field {
+	pct := 0.0
+	if total > 0 {
+		pct = min(float64(done)/float64(total)*100, 100)
+	}
+
+	out := make([]zap.Field, 0, len(fields)+5)
+	out = append(out,
+		zap.String("job_id", jobID),
+		zap.Int("done", done),
+		zap.Int("total", total),
+		zap.Float64("percent", pct),
+	)
+	out = append(out, fields...)
+
+	if start, ok := jobStart(fields); ok && total > 0 && done > 0 {
+		var eta time.Duration
+		if done < total {
+			elapsed := time.Since(start)
+			eta = time.Duration(float64(elapsed) / float64(done) * float64(total-done))
+		}
+		out = append(out, zap.Duration("job_eta", eta))
+	}
+	return out
+}
+
+// jobStart returns the time of a JobStarted field among fields.
+func jobStart(fields []zap.Field) (time.Time, bool) {
+	for _, f := range fields {
+		if f.Key != jobStartedKey {
+			continue
+		}
+		switch f.Type {
+		case zapcore.TimeType:
+			return time.Unix(0, f.Integer), true
+		case zapcore.TimeFullType:
+			t, ok := f.Interface.(time.Time)
+			return t, ok
+		}
+	}
+	return time.Time{}, false
+}
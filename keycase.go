@@ -0,0 +1,155 @@
+package trace
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// KeyCase is a naming convention for field keys.
+type KeyCase int
+
+const (
+	// SnakeCase rewrites keys like "userID" to "user_id".
+	SnakeCase KeyCase = iota
+	// CamelCase rewrites keys like "user_id" to "userId".
+	CamelCase
+)
+
+// WithKeyCase rewrites the keys of top-level fields to the given convention
+// before encoding, so a log schema can be enforced centrally regardless of
+// call sites. Keys nested inside objects are left untouched.
+// Each distinct key is converted once and cached.
+func WithKeyCase(kc KeyCase) Option {
+	convert := toSnakeCase
+	if kc == CamelCase {
+		convert = toCamelCase
+	}
+	cache := &keyCache{convert: convert}
+
+	return wrapCore(func(core zapcore.Core) zapcore.Core {
+		return &keyCaseCore{Core: core, cache: cache}
+	})
+}
+
+type keyCache struct {
+	convert func(string) string
+	keys    sync.Map // original key -> converted key
+}
+
+func (c *keyCache) get(key string) string {
+	if v, ok := c.keys.Load(key); ok {
+		return v.(string)
+	}
+	converted := c.convert(key)
+	c.keys.Store(key, converted)
+	return converted
+}
+
+// rename returns fields with converted keys, copying only if a key changes.
+func (c *keyCache) rename(fields []zapcore.Field) []zapcore.Field {
+	out := fields
+	copied := false
+	for i := range fields {
+		key := c.get(fields[i].Key)
+		if key == fields[i].Key {
+			continue
+		}
+		if !copied {
+			out = append([]zapcore.Field(nil), fields...)
+			copied = true
+		}
+		out[i].Key = key
+	}
+	return out
+}
+
+type keyCaseCore struct {
+	zapcore.Core
+	cache *keyCache
+}
+
+func (c *keyCaseCore) With(fields []zapcore.Field) zapcore.Core {
+	return &keyCaseCore{Core: c.Core.With(c.cache.rename(fields)), cache: c.cache}
+}
+
+func (c *keyCaseCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *keyCaseCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	writeChecked(c.Core, ent, c.cache.rename(fields))
+	return nil
+}
+
+func isKeySeparator(r rune) bool {
+	return r == '_' || r == '-' || r == ' '
+}
+
+// toSnakeCase converts "userID", "HTTPStatus" and "request-id" to
+// "user_id", "http_status" and "request_id".
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	b.Grow(len(s) + 4)
+	for i, r := range runes {
+		if isKeySeparator(r) {
+			b.WriteByte('_')
+			continue
+		}
+		if unicode.IsUpper(r) {
+			if i > 0 && !isKeySeparator(runes[i-1]) {
+				prev := runes[i-1]
+				nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+					b.WriteByte('_')
+				}
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// toCamelCase converts "user_id", "request-ID" and "HTTPStatus" to
+// "userId", "requestID" and "httpStatus".
+func toCamelCase(s string) string {
+	parts := strings.FieldsFunc(s, isKeySeparator)
+	if len(parts) == 0 {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i, p := range parts {
+		r := []rune(p)
+		if i == 0 {
+			lowerLeadingUpper(r)
+		} else {
+			r[0] = unicode.ToUpper(r[0])
+		}
+		b.WriteString(string(r))
+	}
+	return b.String()
+}
+
+// lowerLeadingUpper lowercases a leading run of capitals, keeping the last one
+// when it starts the next word ("HTTPStatus" -> "httpStatus", "ID" -> "id").
+func lowerLeadingUpper(r []rune) {
+	n := 0
+	for n < len(r) && unicode.IsUpper(r[n]) {
+		n++
+	}
+	if n > 1 && n < len(r) && unicode.IsLower(r[n]) {
+		n--
+	}
+	for i := 0; i < n; i++ {
+		r[i] = unicode.ToLower(r[i])
+	}
+}
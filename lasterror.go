@@ -0,0 +1,71 @@
+package trace
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// loggedError is an entry recorded by lastErrorCore.
+type loggedError struct {
+	msg    string
+	fields []zapcore.Field
+	at     time.Time
+}
+
+// lastErrorCore records entries at Error and above, with their bound and call-site
+// fields, as they reach the sinks. It writes nothing itself; other levels cost one
+// comparison in Check.
+type lastErrorCore struct {
+	zapcore.Core
+	last   *atomic.Pointer[loggedError]
+	fields []zapcore.Field // fields bound via With
+}
+
+func (c *lastErrorCore) With(fields []zapcore.Field) zapcore.Core {
+	return &lastErrorCore{Core: c.Core.With(fields), last: c.last, fields: appendFields(c.fields, fields)}
+}
+
+func (c *lastErrorCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	ce = c.Core.Check(ent, ce)
+	if ce != nil && ent.Level >= zapcore.ErrorLevel {
+		ce = ce.AddCore(ent, (*lastErrorRecorder)(c))
+	}
+	return ce
+}
+
+// lastErrorRecorder is the recording side of a lastErrorCore.
+type lastErrorRecorder lastErrorCore
+
+func (r *lastErrorRecorder) Enabled(zapcore.Level) bool { return true }
+
+func (r *lastErrorRecorder) With(fields []zapcore.Field) zapcore.Core {
+	return (*lastErrorRecorder)((*lastErrorCore)(r).With(fields).(*lastErrorCore))
+}
+
+func (r *lastErrorRecorder) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(ent, r)
+}
+
+func (r *lastErrorRecorder) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	r.last.Store(&loggedError{msg: ent.Message, fields: appendFields(r.fields, fields), at: ent.Time})
+	return nil
+}
+
+func (r *lastErrorRecorder) Sync() error { return nil }
+
+// LastError returns the most recent entry at Error or above written by the logger or
+// any logger sharing its root, for reporting the last failure from a health endpoint.
+// The zero values are returned if no error has been logged.
+func (l *sugarLogger) LastError() (msg string, fields []zap.Field, at time.Time) {
+	if l == nil || l.cfg == nil || l.cfg.lastErr == nil {
+		return "", nil, time.Time{}
+	}
+	e := l.cfg.lastErr.Load()
+	if e == nil {
+		return "", nil, time.Time{}
+	}
+	return e.msg, append([]zap.Field(nil), e.fields...), e.at
+}
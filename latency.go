@@ -0,0 +1,84 @@
+package trace
+
+import (
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultLatencyBuckets are used until SetLatencyBuckets is called.
+var defaultLatencyBuckets = newLatencyBuckets([]time.Duration{10 * time.Millisecond, 100 * time.Millisecond, time.Second})
+
+// latencyBuckets holds the labeled buckets set by SetLatencyBuckets; nil means the defaults.
+var latencyBuckets atomic.Pointer[[]latencyBucket]
+
+type latencyBucket struct {
+	upper time.Duration // exclusive; the last bucket has none
+	label string
+}
+
+// Latency logs d in milliseconds together with the bucket it falls in, as a nested
+// object, so dashboards can group latencies straight from the logs:
+//
+//	logger.Info("request served", trace.Latency("latency", 42*time.Millisecond))
+//	// {"latency": {"ms": 42, "bucket": "10ms-100ms"}}
+//
+// The default buckets are "<10ms", "10ms-100ms", "100ms-1s" and ">=1s"; change them
+// with SetLatencyBuckets.
+func Latency(key string, d time.Duration) zap.Field {
+	return zap.Object(key, latency{d: d, bucket: latencyBucketOf(d)})
+}
+
+// SetLatencyBuckets sets the bucket boundaries used by Latency for every logger.
+// The bounds are sorted and deduplicated; n bounds yield n+1 buckets, each bound
+// belonging to the bucket above it. Calling it without bounds restores the defaults.
+func SetLatencyBuckets(bounds ...time.Duration) {
+	if len(bounds) == 0 {
+		latencyBuckets.Store(nil)
+		return
+	}
+	buckets := newLatencyBuckets(bounds)
+	latencyBuckets.Store(&buckets)
+}
+
+func resetLatencyBuckets() {
+	latencyBuckets.Store(nil)
+}
+
+func newLatencyBuckets(bounds []time.Duration) []latencyBucket {
+	bounds = slices.Compact(slices.Sorted(slices.Values(bounds)))
+	buckets := make([]latencyBucket, 0, len(bounds)+1)
+	buckets = append(buckets, latencyBucket{upper: bounds[0], label: "<" + bounds[0].String()})
+	for i := 1; i < len(bounds); i++ {
+		buckets = append(buckets, latencyBucket{upper: bounds[i], label: bounds[i-1].String() + "-" + bounds[i].String()})
+	}
+	return append(buckets, latencyBucket{label: ">=" + bounds[len(bounds)-1].String()})
+}
+
+func latencyBucketOf(d time.Duration) string {
+	buckets := defaultLatencyBuckets
+	if b := latencyBuckets.Load(); b != nil {
+		buckets = *b
+	}
+	last := len(buckets) - 1
+	for _, b := range buckets[:last] {
+		if d < b.upper {
+			return b.label
+		}
+	}
+	return buckets[last].label
+}
+
+type latency struct {
+	d      time.Duration
+	bucket string
+}
+
+func (l latency) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddFloat64("ms", float64(l.d)/float64(time.Millisecond))
+	enc.AddString("bucket", l.bucket)
+	return nil
+}
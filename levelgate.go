@@ -0,0 +1,109 @@
+package trace
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DebugOnly marks f to be emitted only on Debug entries, so verbose detail such as
+// full request bodies can be attached (or bound via With) everywhere without
+// appearing on Info and above:
+//
+//	logger.Info("request", trace.DebugOnly(zap.ByteString("body", body)))
+func DebugOnly(f zap.Field) zap.Field {
+	return UpToLevel(zapcore.DebugLevel, f)
+}
+
+// UpToLevel marks f to be emitted only on entries logged at max or below. Gated
+// fields are resolved by loggers built by this package; any other zap core drops them.
+func UpToLevel(max zapcore.Level, f zap.Field) zap.Field {
+	return zap.Inline(levelGatedField{max: max, field: f})
+}
+
+type levelGatedField struct {
+	max   zapcore.Level
+	field zap.Field
+}
+
+// MarshalLogObject encodes nothing: a gated field that reaches an encoder was not
+// resolved by a levelGateCore, and dropping it is the safe choice.
+func (levelGatedField) MarshalLogObject(zapcore.ObjectEncoder) error { return nil }
+
+func asLevelGated(f zapcore.Field) (levelGatedField, bool) {
+	if f.Type != zapcore.InlineMarshalerType {
+		return levelGatedField{}, false
+	}
+	g, ok := f.Interface.(levelGatedField)
+	return g, ok
+}
+
+// levelGateCore resolves level-gated fields against each entry's level. Gated
+// fields bound via With are held back until Write; all others pass straight through.
+type levelGateCore struct {
+	zapcore.Core
+	gated []levelGatedField
+}
+
+func (c *levelGateCore) With(fields []zapcore.Field) zapcore.Core {
+	first := -1
+	for i := 0; first < 0 && i < len(fields); i++ {
+		if _, ok := asLevelGated(fields[i]); ok {
+			first = i
+		}
+	}
+	if first < 0 {
+		return &levelGateCore{Core: c.Core.With(fields), gated: c.gated}
+	}
+
+	plain := append(make([]zapcore.Field, 0, len(fields)-1), fields[:first]...)
+	gated := append([]levelGatedField(nil), c.gated...)
+	for _, f := range fields[first:] {
+		if g, ok := asLevelGated(f); ok {
+			gated = append(gated, g)
+		} else {
+			plain = append(plain, f)
+		}
+	}
+	return &levelGateCore{Core: c.Core.With(plain), gated: gated}
+}
+
+func (c *levelGateCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *levelGateCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	writeChecked(c.Core, ent, c.resolve(ent.Level, fields))
+	return nil
+}
+
+// resolve unwraps the gated fields admitted at level and drops the rest,
+// returning fields unchanged when there is nothing to resolve.
+func (c *levelGateCore) resolve(level zapcore.Level, fields []zapcore.Field) []zapcore.Field {
+	found := len(c.gated) > 0
+	for i := 0; !found && i < len(fields); i++ {
+		_, found = asLevelGated(fields[i])
+	}
+	if !found {
+		return fields
+	}
+
+	out := make([]zapcore.Field, 0, len(c.gated)+len(fields))
+	for _, g := range c.gated {
+		if level <= g.max {
+			out = append(out, g.field)
+		}
+	}
+	for _, f := range fields {
+		if g, ok := asLevelGated(f); ok {
+			if level <= g.max {
+				out = append(out, g.field)
+			}
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
@@ -0,0 +1,84 @@
+package trace
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// startedAt records when LogStartup ran, for the uptime reported by LogShutdown.
+// It falls back to package initialization time.
+var (
+	processStart = time.Now()
+	startedAt    atomic.Pointer[time.Time]
+)
+
+// LogStartup emits a recognizable startup banner at Info with the application name,
+// version, pid and Go version, followed by fields such as a config summary.
+// Every banner carries lifecycle=startup so operators can grep for the boundary.
+func LogStartup(logger Logger, appName, version string, fields ...zap.Field) {
+	now := time.Now()
+	startedAt.Store(&now)
+
+	all := make([]zap.Field, 0, len(fields)+5)
+	all = append(all,
+		zap.String("lifecycle", "startup"),
+		zap.String("app", appName),
+		zap.String("version", version),
+		zap.Int("pid", os.Getpid()),
+		zap.String("go_version", runtime.Version()),
+	)
+	logger.Info("=== "+appName+" starting ===", append(all, fields...)...)
+}
+
+// LogShutdown emits the matching shutdown banner at Info with the reason, pid and
+// uptime since LogStartup (or since the package was initialized).
+func LogShutdown(logger Logger, reason string) {
+	logger.Info("=== shutting down ===",
+		zap.String("lifecycle", "shutdown"),
+		zap.String("reason", reason),
+		zap.Int("pid", os.Getpid()),
+		zap.Duration("uptime", uptime()),
+	)
+}
+
+// StartHeartbeat logs a "heartbeat" entry at Info every interval, with the uptime,
+// goroutine count and fields, so operators can tell a quiet service from a wedged one.
+// It logs from its own goroutine, which returns once ctx is canceled.
+// A non-positive interval starts nothing.
+func StartHeartbeat(ctx context.Context, logger Logger, interval time.Duration, fields ...zap.Field) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				all := make([]zap.Field, 0, len(fields)+3)
+				all = append(all,
+					zap.String("lifecycle", "heartbeat"),
+					zap.Duration("uptime", uptime()),
+					zap.Int("goroutines", runtime.NumGoroutine()),
+				)
+				logger.Info("heartbeat", append(all, fields...)...)
+			}
+		}
+	}()
+}
+
+// uptime returns the time since LogStartup, or since the package was initialized.
+func uptime() time.Duration {
+	start := processStart
+	if t := startedAt.Load(); t != nil {
+		start = *t
+	}
+	return time.Since(start)
+}
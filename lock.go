@@ -0,0 +1,27 @@
+package trace
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Lock actions for LockEvent.
+const (
+	LockAcquire = "acquire"
+	LockRelease = "release"
+	LockTimeout = "timeout"
+)
+
+// LockEvent returns the standard fields of a distributed lock lifecycle event:
+// lock_name, lock_action (LockAcquire, LockRelease or LockTimeout), lock_holder and
+// lock_waited, the time spent waiting. An empty holder, as when a wait timed out
+// without learning the owner, is omitted.
+func LockEvent(name, action string, holder string, waited time.Duration) []zap.Field {
+	fields := make([]zap.Field, 0, 4)
+	fields = append(fields, zap.String("lock_name", name), zap.String("lock_action", action))
+	if holder != "" {
+		fields = append(fields, zap.String("lock_holder", holder))
+	}
+	return append(fields, zap.Duration("lock_waited", waited))
+}
@@ -0,0 +1,32 @@
+package trace
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLockEvent(t *testing.T) {
+	got := FieldsToMap(LockEvent("orders", LockAcquire, "worker-1", 20*time.Millisecond)...)
+	want := map[string]interface{}{
+		"lock_name":   "orders",
+		"lock_action": "acquire",
+		"lock_holder": "worker-1",
+		"lock_waited": 20 * time.Millisecond,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LockEvent = %v, want %v", got, want)
+	}
+}
+
+func TestLockEventTimeout(t *testing.T) {
+	got := FieldsToMap(LockEvent("orders", LockTimeout, "", 5*time.Second)...)
+	want := map[string]interface{}{
+		"lock_name":   "orders",
+		"lock_action": "timeout",
+		"lock_waited": 5 * time.Second,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LockEvent timeout = %v, want %v without a holder", got, want)
+	}
+}
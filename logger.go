@@ -2,72 +2,223 @@ package trace
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var _ Logger = &sugarLogger{}
 
 // sugarLogger implements the LoggerInterface with a real zap logger
 type sugarLogger struct {
-	Log *zap.Logger
+	log *zap.Logger
+	cfg *loggerConfig // nil for loggers that were not built by New
+
+	// op is the operation path set by BeginOp and opBase the logger it is bound to;
+	// log is opBase with the op_path field. Both are empty outside an operation.
+	op     string
+	opBase *zap.Logger
+
+	// bound lists the keys of fields bound via With, for Snapshot.
+	bound []string
+
+	// gate mutes this logger and those derived from it; nil for loggers that were not built by New.
+	gate *silenceGate
 }
 
+// loggerConfig records how a logger was constructed; it is shared by all children.
+type loggerConfig struct {
+	file   *os.File
+	reopen *reopenableFile    // file sink owned by loggers from NewWithPath
+	rotate *lumberjack.Logger // rotating file sink set by WithRotatingFile
+	once   sync.Map           // keys already logged by WarnOnce
+	sinks  []sinkInfo         // destinations wired at construction
+	json   *atomic.Bool       // selects JSON over console output; nil if the format is fixed
+	rate   *rateCounter       // entries written, for Rate
+	caller bool               // whether entries are annotated with their caller
+
+	level   *zap.AtomicLevel             // adjusted by SetLevel; nil for loggers from NewWithCore
+	lastErr *atomic.Pointer[loggedError] // most recent entry at Error or above, for LastError
+}
+
+// sinkInfo names an output destination and the levels it accepts.
+type sinkInfo struct {
+	name  string
+	level zapcore.LevelEnabler
+}
+
+// warnedOnce tracks WarnOnce keys for loggers without a loggerConfig.
+var warnedOnce sync.Map
+
 // New creates the fastest possible logger configuration
 // level: minimum log level (e.g., zapcore.InfoLevel)
 // prefix: logger name prefix for all messages
 // logFile: optional file to write logs to (pass nil to log to stdout only)
+// opts: optional behaviour such as WithFieldOrder
 // To disable logging completely, use zapcore.Level(127)
-func New(level zapcore.Level, prefix string, logFile *os.File) Logger {
-	// Fastest possible encoder config
-	encoderConfig := zapcore.EncoderConfig{
-		MessageKey:     "msg",
-		LevelKey:       "level",
-		TimeKey:        "ts",
-		NameKey:        "logger",
-		CallerKey:      "",                                                 // disabled for speed
-		StacktraceKey:  "",                                                 // disabled for speed
-		EncodeLevel:    zapcore.CapitalColorLevelEncoder,                   // colored level in caps
-		EncodeTime:     zapcore.TimeEncoderOfLayout("2006-01-02 15:04:05"), // human readable time
-		EncodeDuration: zapcore.StringDurationEncoder,
+//
+// On Unix, the first logger writing to stdout subscribes the process to SIGPIPE
+// with signal.Notify, for the rest of its life. A write to a closed stdout or
+// stderr pipe then fails with EPIPE instead of killing the program, and the logger
+// stops writing to stdout after a warning on stderr. This applies to the whole
+// program, including its own writes to stdout and stderr, and to signal.Notify
+// callers watching for SIGPIPE, which now receive it.
+func New(level zapcore.Level, prefix string, logFile *os.File, opts ...Option) Logger {
+	return NewWithOptions(append([]Option{WithLevel(level), WithPrefix(prefix), WithFile(logFile)}, opts...)...)
+}
+
+// NewWithOptions creates a logger configured entirely by options. Without any it
+// logs at Info level to stdout only, with no name prefix, no caller and console
+// output, exactly like New(InfoLevel, "", nil). WithLevel, WithPrefix, WithFile,
+// WithCaller and WithJSON change those defaults; any other Option applies as in New.
+// Like New, it makes a closed stdout pipe fail writes rather than kill the process.
+func NewWithOptions(opts ...Option) Logger {
+	o := newOptions(opts)
+	switch {
+	case o.rotate != nil:
+		return newTeeLogger(o.level, o.prefix, zapcore.AddSync(o.rotate), &loggerConfig{rotate: o.rotate}, o)
+	case o.writer != nil:
+		return newTeeLogger(o.level, o.prefix, o.writer, &loggerConfig{}, o)
+	}
+	var secondary zapcore.WriteSyncer
+	if o.file != nil {
+		secondary = o.file
 	}
+	return newTeeLogger(o.level, o.prefix, secondary, &loggerConfig{file: o.file}, o)
+}
+
+// NewJSON is like New but writes JSON lines, with lowercase level names and
+// ISO8601 timestamps, for shipping logs to systems such as Loki or Elasticsearch.
+// The format can still be switched at runtime with SetDefaultFormat.
+func NewJSON(level zapcore.Level, prefix string, logFile *os.File, opts ...Option) Logger {
+	return NewWithOptions(append([]Option{WithLevel(level), WithPrefix(prefix), WithFile(logFile), WithJSON(true)}, opts...)...)
+}
+
+// NewWithSink is like New but tees output to an arbitrary write syncer instead of a file,
+// such as a locked buffer in tests or a network connection wrapped with zapcore.AddSync.
+// Writes to sink are serialized, so it need not be safe for concurrent use.
+// A nil sink logs to stdout only.
+func NewWithSink(level zapcore.Level, prefix string, sink zapcore.WriteSyncer, opts ...Option) Logger {
+	return newTeeLogger(level, prefix, sink, &loggerConfig{}, newOptions(opts))
+}
+
+// NewWithCore builds a logger on an existing zap core, such as an observer in tests
+// or an exporter like otlp.Core, applying opts and the package's field handling.
+// Level and output are those of core.
+func NewWithCore(core zapcore.Core, prefix string, opts ...Option) Logger {
+	cfg := &loggerConfig{sinks: []sinkInfo{{name: "core", level: core}}}
+	return newLogger(core, prefix, cfg, newOptions(opts))
+}
+
+// newTeeLogger builds a console (switchable to JSON) logger writing to stdout and, if set, to secondary.
+func newTeeLogger(level zapcore.Level, prefix string, secondary zapcore.WriteSyncer, cfg *loggerConfig, o *options) Logger {
+	enab := o.levelEnabler(level)
+	cfg.json = &atomic.Bool{}
+	cfg.json.Store(o.json)
 
 	// Create stdout writer
-	stdoutSink := zapcore.Lock(os.Stdout)
+	stdout := o.sink(zapcore.Lock(stdoutSink()))
 
 	var core zapcore.Core
 
-	// If logFile is provided, create a multi-output core
-	if logFile != nil {
-		// Create file sink
-		fileSink := zapcore.Lock(logFile)
+	// If a secondary sink is provided, create a multi-output core
+	if secondary != nil {
+		secondarySink := o.sink(zapcore.Lock(secondary))
 
-		// Create a core that writes to both stdout and file
+		cfg.sinks = []sinkInfo{{name: "stdout", level: enab}, {name: cfg.secondaryName(), level: enab}}
+
+		// Create a core that writes to both stdout and the secondary sink
 		core = zapcore.NewTee(
-			zapcore.NewCore(zapcore.NewConsoleEncoder(encoderConfig), stdoutSink, level),
-			zapcore.NewCore(zapcore.NewConsoleEncoder(encoderConfig), fileSink, level),
+			newFormatCore(stdout, enab, cfg.json, o, o.stdoutColor()),
+			newFormatCore(secondarySink, enab, cfg.json, o, false),
 		)
 	} else {
+		cfg.sinks = []sinkInfo{{name: "stdout", level: enab}}
+
 		// Standard stdout-only core
-		core = zapcore.NewCore(
-			zapcore.NewConsoleEncoder(encoderConfig),
-			stdoutSink,
-			level,
-		)
+		core = newFormatCore(stdout, enab, cfg.json, o, o.stdoutColor())
 	}
 
-	if prefix != "" {
-		return &sugarLogger{
-			Log: zap.New(core).Named(prefix),
+	if len(o.routes) > 0 {
+		cores := []zapcore.Core{core}
+		for _, r := range o.routes {
+			cores = append(cores, &nameRouteCore{Core: newFormatCore(o.sink(zapcore.Lock(r.sink)), enab, cfg.json, o, false), route: r})
 		}
+		core = zapcore.NewTee(cores...)
+	}
+	if o.orderedSinks {
+		core = &orderedCore{Core: core, mu: &sync.Mutex{}}
+	}
+
+	return newLogger(core, prefix, cfg, o)
+}
+
+// secondaryName describes the secondary sink of a tee logger.
+func (c *loggerConfig) secondaryName() string {
+	switch {
+	case c.file != nil:
+		return "file:" + c.file.Name()
+	case c.reopen != nil:
+		return "file:" + c.reopen.path
+	case c.rotate != nil:
+		return "file:" + c.rotate.Filename
+	default:
+		return "sink"
+	}
+}
+
+// newEncoderConfig returns the fastest possible encoder config shared by all constructors.
+// Its colored levels are replaced by plain ones for sinks that are not a terminal.
+func newEncoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
+		MessageKey:     "msg",
+		LevelKey:       "level",
+		TimeKey:        "ts",
+		NameKey:        "logger",
+		CallerKey:      "",                                                 // disabled for speed
+		StacktraceKey:  "",                                                 // disabled for speed
+		EncodeLevel:    zapcore.CapitalColorLevelEncoder,                   // colored level in caps
+		EncodeTime:     zapcore.TimeEncoderOfLayout("2006-01-02 15:04:05"), // human readable time
+		EncodeDuration: zapcore.StringDurationEncoder,
 	}
+}
+
+// newLogger records errors for LastError, applies the core wrappers in o, resolves
+// level-gated fields, installs the silence and pause gates, rate counter and error
+// policy, and names the logger.
+func newLogger(core zapcore.Core, prefix string, cfg *loggerConfig, o *options) *sugarLogger {
+	cfg.lastErr = &atomic.Pointer[loggedError]{}
+	cfg.level = o.atomicLevel
+	cfg.caller = o.caller
+	core = &lastErrorCore{Core: core, last: cfg.lastErr}
+	core = o.wrap(core)
+	core = &levelGateCore{Core: core}
+
+	gate := &silenceGate{}
+	core = &gateCore{Core: core, gate: gate}
+	core = &pauseCore{Core: core}
+	cfg.rate = &rateCounter{}
+	core = zapcore.RegisterHooks(core, cfg.rate.observe)
+	core = &errorPolicyCore{Core: core}
 
 	// Build the logger with minimal options for speed
-	return &sugarLogger{
-		Log: zap.New(core),
+	zapOpts := []zap.Option{zap.WithFatalHook(fatalHook{})}
+	if o.caller {
+		zapOpts = append(zapOpts, zap.AddCaller(), zap.AddCallerSkip(1))
 	}
+	log := zap.New(core, append(zapOpts, o.zapOptions...)...)
+	if prefix != "" {
+		log = log.Named(prefix)
+	}
+	return &sugarLogger{log: log, cfg: cfg, gate: gate}
 }
 
 func NewChildLogger(parent Logger, prefix string) Logger {
@@ -75,71 +226,309 @@ func NewChildLogger(parent Logger, prefix string) Logger {
 		return NewNoopLogger()
 	}
 
-	if prefix != "" {
-		return &sugarLogger{
-			Log: parent.Zap().Named(prefix),
+	if p, ok := parent.(*sugarLogger); ok {
+		if prefix != "" {
+			return p.Named(prefix)
 		}
+		return p.derive(func(log *zap.Logger) *zap.Logger { return log })
 	}
 
-	return &sugarLogger{
-		Log: parent.Zap(),
+	if prefix != "" {
+		return &sugarLogger{log: parent.Zap().Named(prefix)}
 	}
+	return &sugarLogger{log: parent.Zap()}
 }
 
 // Debug logs a debug message
 func (l *sugarLogger) Debug(msg string, fields ...zap.Field) {
-	if l.Log != nil {
-		l.Log.Debug(msg, fields...)
+	if l.log != nil {
+		l.log.Debug(msg, fields...)
 	}
 }
 
 // Info logs an info message
 func (l *sugarLogger) Info(msg string, fields ...zap.Field) {
-	if l.Log != nil {
-		l.Log.Info(msg, fields...)
+	if l.log != nil {
+		l.log.Info(msg, fields...)
 	}
 }
 
 // Warn logs a warning message
 func (l *sugarLogger) Warn(msg string, fields ...zap.Field) {
-	if l.Log != nil {
-		l.Log.Warn(msg, fields...)
+	if l.log != nil {
+		l.log.Warn(msg, fields...)
 	}
 }
 
 // Error logs an error message
 func (l *sugarLogger) Error(msg string, fields ...zap.Field) {
-	if l.Log != nil {
-		l.Log.Error(msg, fields...)
+	if l.log != nil {
+		l.log.Error(msg, fields...)
 	}
 }
 
 // Fatal logs a fatal message and exits
 func (l *sugarLogger) Fatal(msg string, fields ...zap.Field) {
-	if l.Log != nil {
-		l.Log.Fatal(msg, fields...)
+	if l.log != nil {
+		l.log.Fatal(msg, fields...)
 	}
 }
 
+// Log logs a message at the given level, including DPanic, Panic and Fatal with their
+// usual panic and exit behaviour. Unknown levels are logged at Info after a warning.
+func (l *sugarLogger) Log(level zapcore.Level, msg string, fields ...zap.Field) {
+	if l == nil || l.log == nil {
+		return
+	}
+	l.log.Log(l.knownLevel(level), msg, fields...)
+}
+
+// logSkip is Log for helpers in this package that log on behalf of their caller,
+// such as Fields.LogAndRelease: under WithCaller it skips skip more frames so the
+// reported caller is the helper's caller rather than the helper.
+func (l *sugarLogger) logSkip(skip int, level zapcore.Level, msg string, fields ...zap.Field) {
+	if l == nil || l.log == nil {
+		return
+	}
+	log := l.log
+	if l.cfg != nil && l.cfg.caller {
+		log = log.WithOptions(zap.AddCallerSkip(skip))
+	}
+	log.Log(l.knownLevel(level), msg, fields...)
+}
+
+// knownLevel returns level, or Info after a warning if level is not one zap logs.
+func (l *sugarLogger) knownLevel(level zapcore.Level) zapcore.Level {
+	if level < zapcore.DebugLevel || level > zapcore.FatalLevel {
+		l.log.Warn("unknown log level, logging at info", zap.Int8("level", int8(level)))
+		return zapcore.InfoLevel
+	}
+	return level
+}
+
+// LogAt logs a message at level like Log, but with t as the entry timestamp instead
+// of the current time, for replaying or backfilling historical events. A zero t
+// keeps the current time.
+func (l *sugarLogger) LogAt(t time.Time, level zapcore.Level, msg string, fields ...zap.Field) {
+	if l == nil || l.log == nil {
+		return
+	}
+	if ce := l.log.Check(l.knownLevel(level), msg); ce != nil {
+		if !t.IsZero() {
+			ce.Time = t
+		}
+		ce.Write(fields...)
+	}
+}
+
+// Sync flushes buffered output, such as that of WithBuffer or a file, so that it is
+// not lost when the process exits. Syncing stdout fails with an error such as
+// "invalid argument" or "inappropriate ioctl for device" on some platforms when it
+// is a terminal or pipe; such errors are harmless and callers usually ignore them.
+func (l *sugarLogger) Sync() error {
+	if l == nil || l.log == nil {
+		return nil
+	}
+	return l.log.Sync()
+}
+
+// WarnOnce logs a warning only the first time key is seen. The set of seen keys is
+// shared by the logger and everything derived from it, so a warning emitted through
+// request-scoped children is still logged only once.
+func (l *sugarLogger) WarnOnce(key, msg string, fields ...zap.Field) {
+	if l == nil || l.log == nil {
+		return
+	}
+	seen := &warnedOnce
+	if l.cfg != nil {
+		seen = &l.cfg.once
+	}
+	if _, loaded := seen.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+	l.log.Warn(msg, fields...)
+}
+
 // With returns a child logger with additional structured fields included in every log.
 func (l *sugarLogger) With(fields ...zap.Field) Logger {
-	if l == nil || l.Log == nil {
+	if l == nil || l.log == nil {
 		return l
 	}
-	return &sugarLogger{Log: l.Log.With(fields...)}
+	child := l.derive(func(log *zap.Logger) *zap.Logger { return log.With(fields...) })
+	child.bound = make([]string, 0, len(l.bound)+len(fields))
+	child.bound = append(child.bound, l.bound...)
+	for _, f := range fields {
+		if f.Key != "" {
+			child.bound = append(child.bound, f.Key)
+		}
+	}
+	return child
+}
+
+// Group returns a child logger that nests all subsequent fields, bound or passed per
+// call, under name: l.Group("db").Info("query", zap.String("table", "users")) logs
+// {"db": {"table": "users"}}. Fields bound before the call stay at their level.
+func (l *sugarLogger) Group(name string) Logger {
+	if l == nil || l.log == nil {
+		return l
+	}
+	return l.With(zap.Namespace(name))
+}
+
+// WithDynamic returns a child logger that adds key with the current value of fn to every
+// entry it writes. Unlike With, which captures a value once, fn is evaluated per entry,
+// so it runs on the hot path of every enabled log call and must be cheap and safe for
+// concurrent use. Entries filtered out by level never call fn.
+func (l *sugarLogger) WithDynamic(key string, fn func() interface{}) Logger {
+	if l == nil || l.log == nil || fn == nil {
+		return l
+	}
+	return l.derive(func(log *zap.Logger) *zap.Logger {
+		return log.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &dynamicCore{Core: core, key: key, fn: fn}
+		}))
+	})
 }
 
 // Named returns a child logger with a name scope (logger name prefix).
 func (l *sugarLogger) Named(name string) Logger {
-	if l == nil || l.Log == nil {
+	if l == nil || l.log == nil {
 		return l
 	}
-	return &sugarLogger{Log: l.Log.Named(name)}
+	return l.derive(func(log *zap.Logger) *zap.Logger { return log.Named(name) })
+}
+
+// Tagged returns a child logger that prepends "[tag] " to every message. Unlike Named,
+// which sets the structured logger name, it changes the human-readable text that some
+// log viewers key on. Nested tags compose left to right: "[outer] [inner] msg".
+func (l *sugarLogger) Tagged(tag string) Logger {
+	if l == nil || l.log == nil {
+		return l
+	}
+	return l.derive(func(log *zap.Logger) *zap.Logger {
+		return log.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &tagCore{Core: core, prefix: "[" + tag + "] "}
+		}))
+	})
+}
+
+// BeginOp returns a child logger for a nested operation. Its entries carry an op_path
+// field listing the enclosing operations, e.g. "import > parse > validate".
+func (l *sugarLogger) BeginOp(name string) Logger {
+	if l == nil || l.log == nil {
+		return l
+	}
+	base, op := l.log, name
+	if l.op != "" {
+		base, op = l.opBase, l.op+" > "+name
+	}
+	base, gate := l.childGate(base)
+	return &sugarLogger{log: base.With(zap.String("op_path", op)), cfg: l.cfg, op: op, opBase: base, bound: l.bound, gate: gate}
+}
+
+// derive returns a child logger built by applying fn to l's zap logger. Inside an
+// operation fn is applied beneath the op_path field, so it is never bound twice.
+func (l *sugarLogger) derive(fn func(*zap.Logger) *zap.Logger) *sugarLogger {
+	if l.op == "" {
+		log, gate := l.childGate(fn(l.log))
+		return &sugarLogger{log: log, cfg: l.cfg, bound: l.bound, gate: gate}
+	}
+	base, gate := l.childGate(fn(l.opBase))
+	return &sugarLogger{log: base.With(zap.String("op_path", l.op)), cfg: l.cfg, op: l.op, opBase: base, bound: l.bound, gate: gate}
+}
+
+// childGate wraps log, the zap logger of a child of l, in a silence gate of its own
+// nested in l's, so that l.Silence mutes the child but the child's Silence leaves l alone.
+func (l *sugarLogger) childGate(log *zap.Logger) (*zap.Logger, *silenceGate) {
+	if l.gate == nil {
+		return log, nil
+	}
+	gate := &silenceGate{parent: l.gate}
+	return log.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &gateCore{Core: core, gate: gate}
+	})), gate
+}
+
+// Silence mutes the logger and every logger derived from it until restore is called,
+// leaving its parent and siblings logging. Restore is idempotent; concurrent silences
+// stay in effect until all are restored.
+func (l *sugarLogger) Silence() (restore func()) {
+	if l == nil || l.gate == nil {
+		return func() {}
+	}
+	return l.gate.silence()
+}
+
+// SetLevel changes the minimum enabled level of the logger, every logger derived
+// from the same root and all of their sinks, taking effect for the next entry. It
+// has no effect on loggers built by NewWithCore, whose level is that of their core.
+func (l *sugarLogger) SetLevel(level zapcore.Level) {
+	if l != nil && l.cfg != nil && l.cfg.level != nil {
+		l.cfg.level.SetLevel(level)
+	}
+}
+
+// GetLevel returns the minimum level set at construction or by SetLevel. During an
+// incident under WithAdaptiveLevel the effective level may differ.
+func (l *sugarLogger) GetLevel() zapcore.Level {
+	if l == nil || l.log == nil {
+		return zapcore.InvalidLevel
+	}
+	if l.cfg != nil && l.cfg.level != nil {
+		return l.cfg.level.Level()
+	}
+	return l.log.Level()
+}
+
+// Slog returns a *slog.Logger backed by this logger, for dependencies that require one.
+func (l *sugarLogger) Slog() *slog.Logger {
+	if l == nil || l.log == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return slog.New(NewSlogHandler(l))
 }
 
 // Zap returns the underlying zap logger if needed
 func (l *sugarLogger) Zap() *zap.Logger {
-	return l.Log
+	return l.log
+}
+
+// String describes the logger's wiring, e.g.
+// SugarLogger{level=info, name=http, file=true, sinks=[stdout file:/var/log/app.log]}.
+// file reports whether any sink is a file, rotating or not.
+func (l *sugarLogger) String() string {
+	if l == nil || l.log == nil {
+		return "SugarLogger{<nil>}"
+	}
+	name := l.log.Name()
+	if name == "" {
+		name = "<root>"
+	}
+	sinks := []string{}
+	file := false
+	if l.cfg != nil {
+		for _, s := range l.cfg.sinks {
+			sinks = append(sinks, s.name)
+			file = file || strings.HasPrefix(s.name, "file:")
+		}
+	}
+	return fmt.Sprintf("SugarLogger{level=%s, name=%s, file=%t, sinks=%v}", l.log.Level(), name, file, sinks)
+}
+
+// explainRouting lists the destinations an entry at level would be written to,
+// taking sink levels and silencing into account. It is a debugging aid for tests
+// verifying multi-sink configurations.
+func (l *sugarLogger) explainRouting(level zapcore.Level) []string {
+	if l == nil || l.log == nil || l.cfg == nil || !l.log.Core().Enabled(level) {
+		return nil
+	}
+	var names []string
+	for _, s := range l.cfg.sinks {
+		if s.level.Enabled(level) {
+			names = append(names, s.name)
+		}
+	}
+	return names
 }
 
 // Log level constants
@@ -160,21 +549,44 @@ func DisabledLevel() zapcore.Level {
 
 type loggerCtxKey struct{}
 
-// LoggerToContext attaches the provided logger to the context.
-func LoggerToContext(ctx context.Context, l Logger) context.Context {
+// ContextWithLogger attaches the provided logger to the context, so code further
+// down the call chain can retrieve it with LoggerFromContext.
+func ContextWithLogger(ctx context.Context, l Logger) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	return context.WithValue(ctx, loggerCtxKey{}, l)
 }
 
-// LoggerFromContext retrieves a logger from context or returns a no-op logger if absent.
+// LoggerToContext attaches the provided logger to the context; it is the same as
+// ContextWithLogger.
+func LoggerToContext(ctx context.Context, l Logger) context.Context {
+	return ContextWithLogger(ctx, l)
+}
+
+// LoggerFromContext retrieves a logger from context or returns the default logger
+// if absent, so it never returns nil and callers can use it unconditionally.
 // Typical usage:
 //   - component-scoped: base := root.Named("http").With(zap.String("component","http"))
 //   - request-scoped:  reqLog := base.With(zap.String("request_id", rid))
-//   - ctx = WithLogger(ctx, reqLog)
+//   - ctx = ContextWithLogger(ctx, reqLog)
+//   - downstream:       trace.LoggerFromContext(ctx).Info("loaded")
 func LoggerFromContext(ctx context.Context) Logger {
+	if l, ok := contextLogger(ctx); ok {
+		return l
+	}
+	return GetDefaultLogger()
+}
+
+// contextLogger returns the logger attached to ctx, if any.
+func contextLogger(ctx context.Context) (Logger, bool) {
+	if ctx == nil {
+		return nil, false
+	}
 	if v := ctx.Value(loggerCtxKey{}); v != nil {
 		if l, ok := v.(Logger); ok && l != nil && l.Zap() != nil {
-			return l
+			return l, true
 		}
 	}
-	return NewNoopLogger()
+	return nil, false
 }
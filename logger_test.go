@@ -0,0 +1,271 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogAt(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := NewWithCore(core, "", WithCaller(true))
+
+	past := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	before := time.Now()
+	logger.LogAt(past, zapcore.WarnLevel, "backfilled")
+	logger.LogAt(time.Time{}, zapcore.InfoLevel, "now")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if got := entries[0].Time; !got.Equal(past) {
+		t.Errorf("backfilled time = %v, want %v", got, past)
+	}
+	if got := entries[1].Time; got.Before(before) {
+		t.Errorf("zero-t time = %v, want the current time", got)
+	}
+	for _, e := range entries {
+		if got := filepath.Base(e.Caller.File); got != "logger_test.go" {
+			t.Errorf("%q: caller file = %s, want logger_test.go", e.Message, got)
+		}
+	}
+}
+
+func TestExplainRouting(t *testing.T) {
+	core := zapcore.NewTee(
+		zapcore.NewCore(zapcore.NewJSONEncoder(newJSONEncoderConfig()), zapcore.AddSync(io.Discard), zapcore.InfoLevel),
+		zapcore.NewCore(zapcore.NewJSONEncoder(newJSONEncoderConfig()), zapcore.AddSync(io.Discard), zapcore.ErrorLevel),
+	)
+	cfg := &loggerConfig{sinks: []sinkInfo{{name: "stdout", level: zapcore.InfoLevel}, {name: "file:app.log", level: zapcore.ErrorLevel}}}
+	logger := newLogger(core, "", cfg, newOptions(nil))
+
+	tests := []struct {
+		level zapcore.Level
+		want  []string
+	}{
+		{zapcore.DebugLevel, nil},
+		{zapcore.WarnLevel, []string{"stdout"}},
+		{zapcore.ErrorLevel, []string{"stdout", "file:app.log"}},
+	}
+	for _, tt := range tests {
+		if got := logger.explainRouting(tt.level); !slices.Equal(got, tt.want) {
+			t.Errorf("explainRouting(%s) = %q, want %q", tt.level, got, tt.want)
+		}
+	}
+
+	restore := logger.Silence()
+	defer restore()
+	if got := logger.explainRouting(zapcore.ErrorLevel); got != nil {
+		t.Errorf("explainRouting(error) while silenced = %q, want none", got)
+	}
+}
+
+func TestNewJSON(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "json-*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	logger := NewJSON(zapcore.InfoLevel, "svc", f)
+	logger.Info("started", Int("port", 8080))
+	if err := logger.Sync(); err != nil {
+		t.Logf("sync: %v", err)
+	}
+
+	b, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal(b, &entry); err != nil {
+		t.Fatalf("output %q is not JSON: %v", b, err)
+	}
+	for _, key := range []string{"level", "msg", "ts"} {
+		if _, ok := entry[key]; !ok {
+			t.Errorf("entry %v has no %q", entry, key)
+		}
+	}
+	if entry["level"] != "info" || entry["msg"] != "started" || entry["port"] != float64(8080) {
+		t.Errorf("entry = %v, want plain info level, msg and port", entry)
+	}
+}
+
+// captureStdout replaces os.Stdout with a pipe while fn runs and returns what was
+// written to it. Loggers must be built inside fn to write to the pipe.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = io.Copy(&buf, r)
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = w
+	func() {
+		defer func() {
+			os.Stdout = stdout
+			w.Close()
+		}()
+		fn()
+	}()
+	<-done
+	r.Close()
+	return buf.String()
+}
+
+func TestNewWithOptionsDefaults(t *testing.T) {
+	clock := WithClock(func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) })
+	write := func(logger Logger) {
+		logger.Debug("hidden at info")
+		logger.Info("visible", Str("key", "value"))
+		_ = logger.Sync()
+	}
+
+	got := captureStdout(t, func() { write(NewWithOptions(clock)) })
+	want := captureStdout(t, func() { write(New(zapcore.InfoLevel, "", nil, clock)) })
+	if got != want {
+		t.Errorf("NewWithOptions() output differs from New(InfoLevel, \"\", nil):\n%q\n%q", got, want)
+	}
+	if !strings.Contains(got, "visible") || strings.Contains(got, "hidden at info") {
+		t.Errorf("output = %q, want only the info entry", got)
+	}
+}
+
+func TestSync(t *testing.T) {
+	defer Reset()
+
+	if err := NewNoopLogger().Sync(); err != nil {
+		t.Errorf("noop Sync = %v, want nil", err)
+	}
+	if err := (&sugarLogger{}).Sync(); err != nil {
+		t.Errorf("zero logger Sync = %v, want nil", err)
+	}
+	if err := Sync(); err != nil {
+		t.Errorf("package Sync without a default logger = %v, want nil", err)
+	}
+
+	// Syncing stdout may fail harmlessly on a terminal or pipe; it must not panic.
+	captureStdout(t, func() {
+		logger := New(zapcore.InfoLevel, "", nil)
+		_ = logger.Sync()
+		SetDefaultLogger(logger)
+		_ = Sync()
+	})
+
+	f, err := os.CreateTemp(t.TempDir(), "sync-*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	fileLogger := NewWithSink(zapcore.InfoLevel, "", f)
+	fileLogger.Info("flushed")
+	_ = fileLogger.Sync()
+	if b, _ := os.ReadFile(f.Name()); !strings.Contains(string(b), "flushed") {
+		t.Errorf("file holds %q after Sync, want the entry", b)
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "level-*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	stdout := captureStdout(t, func() {
+		logger := New(zapcore.InfoLevel, "", f)
+		logger.Debug("dropped at info")
+		logger.SetLevel(zapcore.DebugLevel)
+		logger.Debug("logged at debug")
+		if got := logger.GetLevel(); got != zapcore.DebugLevel {
+			t.Errorf("GetLevel = %s, want debug", got)
+		}
+		_ = logger.Sync()
+	})
+	file, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for sink, out := range map[string]string{"stdout": stdout, "file": string(file)} {
+		if strings.Contains(out, "dropped at info") {
+			t.Errorf("%s has the Debug entry logged before SetLevel:\n%s", sink, out)
+		}
+		if !strings.Contains(out, "logged at debug") {
+			t.Errorf("%s lacks the Debug entry logged after SetLevel:\n%s", sink, out)
+		}
+	}
+}
+
+func TestLoggerFromContext(t *testing.T) {
+	defer Reset()
+	logger, _ := NewMemory()
+	if got := LoggerFromContext(ContextWithLogger(context.Background(), logger)); got != logger {
+		t.Errorf("round-trip returned %v, want the attached logger", got)
+	}
+	if got := LoggerFromContext(LoggerToContext(nil, logger)); got != logger {
+		t.Errorf("round-trip from a nil ctx returned %v, want the attached logger", got)
+	}
+
+	if got := LoggerFromContext(context.Background()); got == nil || got != GetDefaultLogger() {
+		t.Errorf("without a logger got %v, want the default logger", got)
+	}
+	def, _ := NewMemory()
+	SetDefaultLogger(def)
+	for name, ctx := range map[string]context.Context{
+		"empty":      context.Background(),
+		"nil":        nil,
+		"nil logger": ContextWithLogger(context.Background(), nil),
+	} {
+		if got := LoggerFromContext(ctx); got != def {
+			t.Errorf("%s ctx: got %v, want the default logger", name, got)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	core, _ := observer.New(zapcore.DebugLevel)
+	tests := []struct {
+		name   string
+		logger Logger
+		want   string
+	}{
+		{"stdout", NewWithOptions(WithPrefix("http")), "SugarLogger{level=info, name=http, file=false, sinks=[stdout]}"},
+		{"writer", NewWithOptions(WithWriter(&buf)), "SugarLogger{level=info, name=<root>, file=false, sinks=[stdout sink]}"},
+		{
+			"rotating file",
+			NewWithOptions(WithLevel(zapcore.WarnLevel), WithRotatingFile(filepath.Join(dir, "app.log"), RotateConfig{})),
+			"SugarLogger{level=warn, name=<root>, file=true, sinks=[stdout file:" + filepath.Join(dir, "app.log") + "]}",
+		},
+		{"core", NewWithCore(core, "app"), "SugarLogger{level=debug, name=app, file=false, sinks=[core]}"},
+		{"nil", (*sugarLogger)(nil), "SugarLogger{<nil>}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprint(tt.logger); got != tt.want {
+				t.Errorf("String() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
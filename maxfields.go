@@ -0,0 +1,51 @@
+package trace
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultMaxFields is the limit used by WithMaxFields when n is not positive.
+const DefaultMaxFields = 512
+
+// WithMaxFields protects the pipeline from pathological entries, such as fields
+// appended in a buggy loop, by keeping at most n fields per log call (and per With
+// call). The dropped fields are replaced by a fields_truncated field holding their count.
+func WithMaxFields(n int) Option {
+	if n <= 0 {
+		n = DefaultMaxFields
+	}
+	return wrapCore(func(core zapcore.Core) zapcore.Core {
+		return &maxFieldsCore{Core: core, max: n}
+	})
+}
+
+type maxFieldsCore struct {
+	zapcore.Core
+	max int
+}
+
+func (c *maxFieldsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &maxFieldsCore{Core: c.Core.With(c.truncate(fields)), max: c.max}
+}
+
+func (c *maxFieldsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *maxFieldsCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	writeChecked(c.Core, ent, c.truncate(fields))
+	return nil
+}
+
+func (c *maxFieldsCore) truncate(fields []zapcore.Field) []zapcore.Field {
+	if len(fields) <= c.max {
+		return fields
+	}
+	out := make([]zapcore.Field, c.max, c.max+1)
+	copy(out, fields)
+	return append(out, zap.Int("fields_truncated", len(fields)-c.max))
+}
@@ -0,0 +1,57 @@
+package trace
+
+import (
+	"bytes"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// maxMemoryLines bounds how many lines a memory logger retains; older lines are discarded.
+const maxMemoryLines = 10000
+
+// NewMemory creates a Debug-level logger that keeps its output in memory instead of
+// writing to stdout, along with a func returning a snapshot of the lines logged so far.
+// Lines use the console format without color codes, making it a simple and
+// deterministic way to assert on output in tests. Only the most recent
+// maxMemoryLines lines are kept. Both the logger and the snapshot func are safe
+// for concurrent use.
+func NewMemory(opts ...Option) (Logger, func() []string) {
+	encoderConfig := newEncoderConfig()
+	encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+
+	o := newOptions(opts)
+	sink := &memorySink{}
+	enab := o.levelEnabler(zapcore.DebugLevel)
+	core := zapcore.NewCore(zapcore.NewConsoleEncoder(encoderConfig), o.sink(sink), enab)
+
+	cfg := &loggerConfig{sinks: []sinkInfo{{name: "memory", level: enab}}}
+	return newLogger(core, "", cfg, o), sink.lines
+}
+
+// memorySink is a bounded, concurrency-safe WriteSyncer that stores output line by line.
+type memorySink struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (s *memorySink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, line := range bytes.Split(bytes.TrimSuffix(p, []byte("\n")), []byte("\n")) {
+		s.entries = append(s.entries, string(line))
+	}
+	if over := len(s.entries) - maxMemoryLines; over > 0 {
+		s.entries = append(s.entries[:0], s.entries[over:]...)
+	}
+	return len(p), nil
+}
+
+func (s *memorySink) Sync() error { return nil }
+
+func (s *memorySink) lines() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.entries...)
+}
@@ -0,0 +1,23 @@
+package trace
+
+import "go.uber.org/zap"
+
+// Count logs a counter increment through the default logger, for log-to-metrics
+// pipelines that derive metrics from centrally shipped logs. Each call writes one
+// Info entry with the message "metric" and the fields
+//
+//	{"metric": "counter", "name": name, "delta": delta, ...tags}
+//
+// Pipelines should select entries by metric == "counter", sum delta per name and
+// treat the remaining fields as tags, so name them the way the metrics backend
+// expects labels and keep their values low-cardinality. Entries are subject to the
+// default logger's level, sampling and filters like any other.
+func Count(name string, delta int64, tags ...zap.Field) {
+	fields := make([]zap.Field, 0, len(tags)+3)
+	fields = append(fields,
+		zap.String("metric", "counter"),
+		zap.String("name", name),
+		zap.Int64("delta", delta),
+	)
+	GetDefaultLogger().Info("metric", append(fields, tags...)...)
+}
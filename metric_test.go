@@ -0,0 +1,30 @@
+package trace
+
+import (
+	"reflect"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCount(t *testing.T) {
+	defer Reset()
+	core, logs := observer.New(zapcore.DebugLevel)
+	SetDefaultLogger(NewWithCore(core, ""))
+
+	Count("orders_created", 3, Str("region", "eu"))
+
+	entries := logs.AllUntimed()
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.Level != zapcore.InfoLevel || e.Message != "metric" {
+		t.Errorf("entry = %s %q, want info \"metric\"", e.Level, e.Message)
+	}
+	want := map[string]interface{}{"metric": "counter", "name": "orders_created", "delta": int64(3), "region": "eu"}
+	if got := e.ContextMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("fields = %v, want %v", got, want)
+	}
+}
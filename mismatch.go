@@ -0,0 +1,35 @@
+package trace
+
+import (
+	"reflect"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Mismatch logs an expected and an actual value side by side, as a nested object
+// holding want, got and whether they are equal according to reflect.DeepEqual:
+//
+//	logger.WarnOnce("cache-size", "invariant violated", trace.Mismatch("entries", want, got))
+//	// {"entries": {"want": 10, "got": 12, "equal": false}}
+//
+// This gives test helpers and runtime invariant checks one way to report mismatches.
+// want and got are encoded by reflection.
+func Mismatch(key string, want, got interface{}) zap.Field {
+	return zap.Object(key, mismatch{want: want, got: got})
+}
+
+type mismatch struct {
+	want, got interface{}
+}
+
+func (m mismatch) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if err := enc.AddReflected("want", m.want); err != nil {
+		return err
+	}
+	if err := enc.AddReflected("got", m.got); err != nil {
+		return err
+	}
+	enc.AddBool("equal", reflect.DeepEqual(m.want, m.got))
+	return nil
+}
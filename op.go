@@ -0,0 +1,34 @@
+package trace
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// StartSlowOp starts timing an operation and returns a func to call when it completes.
+// The completion is logged only if the operation failed (at Error) or took longer
+// than threshold (at Warn); fast, successful operations produce no output.
+//
+//	done := trace.StartSlowOp(logger, "db.query", 200*time.Millisecond, zap.String("table", "users"))
+//	err := query()
+//	done(err)
+func StartSlowOp(logger Logger, name string, threshold time.Duration, fields ...zap.Field) func(err error) {
+	start := time.Now()
+	return func(err error) {
+		elapsed := time.Since(start)
+		if err == nil && elapsed <= threshold {
+			return
+		}
+
+		all := make([]zap.Field, 0, len(fields)+4)
+		all = append(all, zap.String("op", name), zap.Duration("elapsed", elapsed), zap.Duration("threshold", threshold))
+		all = append(all, fields...)
+
+		if err != nil {
+			logger.Error("operation failed", append(all, zap.Error(err))...)
+			return
+		}
+		logger.Warn("slow operation", all...)
+	}
+}
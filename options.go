@@ -0,0 +1,202 @@
+package trace
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Option configures optional behaviour of a logger built by New.
+type Option func(*options)
+
+// options collects everything an Option can change.
+type options struct {
+	// wrappers decorate the sink core in order; the last one is outermost.
+	wrappers []func(zapcore.Core) zapcore.Core
+
+	// zapOptions are passed to zap.New.
+	zapOptions []zap.Option
+
+	// bufferSize and flushInterval enable buffered output when bufferSize > 0.
+	bufferSize    int
+	flushInterval time.Duration
+
+	// routes are the extra sinks for named loggers added by WithNameRoute.
+	routes []nameRoute
+
+	// atomicLevel is the adjustable level built by levelEnabler, for SetLevel.
+	atomicLevel *zap.AtomicLevel
+
+	// adaptive is the policy set by WithAdaptiveLevel, and adaptiveLevel the
+	// enabler built from it by levelEnabler.
+	adaptive      *AdaptivePolicy
+	adaptiveLevel *adaptiveLevel
+
+	// msgReplacer rewrites newlines in JSON messages, set by WithMessageNewlines.
+	msgReplacer *strings.Replacer
+
+	// level, prefix and file configure loggers built by NewWithOptions.
+	level  zapcore.Level
+	prefix string
+	file   *os.File
+
+	// rotate is the rotating file writer set by WithRotatingFile, and writer the
+	// sink set by WithWriter.
+	rotate *lumberjack.Logger
+	writer zapcore.WriteSyncer
+
+	// json starts the logger in JSON rather than console format, and caller
+	// annotates entries with the calling file and line.
+	json   bool
+	caller bool
+
+	// timeFormat and utc change how entry timestamps are written.
+	timeFormat string
+	utc        bool
+
+	// color forces colored console output to stdout on or off; nil detects a terminal.
+	color *bool
+
+	// orderedSinks serializes writes across sinks, set by WithOrderedSinks.
+	orderedSinks bool
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// wrap applies the configured core wrappers to core.
+func (o *options) wrap(core zapcore.Core) zapcore.Core {
+	for _, w := range o.wrappers {
+		core = w(core)
+	}
+	if o.adaptiveLevel != nil {
+		core = zapcore.RegisterHooks(core, o.adaptiveLevel.observe)
+	}
+	return core
+}
+
+// levelEnabler returns the level filter for sinks of a logger at level, which
+// SetLevel can change later. It must be called before wrap so that an adaptive
+// level also observes the entries written.
+func (o *options) levelEnabler(level zapcore.Level) zapcore.LevelEnabler {
+	atomicLevel := zap.NewAtomicLevelAt(level)
+	o.atomicLevel = &atomicLevel
+	if o.adaptive == nil {
+		return atomicLevel
+	}
+	o.adaptiveLevel = newAdaptiveLevel(atomicLevel, *o.adaptive)
+	return o.adaptiveLevel
+}
+
+// sink decorates an output WriteSyncer according to the options.
+func (o *options) sink(ws zapcore.WriteSyncer) zapcore.WriteSyncer {
+	if o.bufferSize > 0 {
+		return newBufferedSink(ws, o.bufferSize, o.flushInterval)
+	}
+	return ws
+}
+
+// wrapCore returns an Option that decorates the logger's core with w.
+func wrapCore(w func(zapcore.Core) zapcore.Core) Option {
+	return func(o *options) {
+		o.wrappers = append(o.wrappers, w)
+	}
+}
+
+// WithClock makes the logger take entry timestamps from now instead of the real
+// clock, so tests can assert on complete output including the ts field.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		if now != nil {
+			o.zapOptions = append(o.zapOptions, zap.WithClock(clockFunc(now)))
+		}
+	}
+}
+
+// clockFunc adapts a func to zapcore.Clock.
+type clockFunc func() time.Time
+
+func (f clockFunc) Now() time.Time { return f() }
+
+func (f clockFunc) NewTicker(d time.Duration) *time.Ticker { return time.NewTicker(d) }
+
+// WithSchemaVersion binds a log_schema field holding v to every entry, so downstream
+// parsers can tell which version of the log schema produced a line. The field is
+// encoded once, when the logger is built.
+func WithSchemaVersion(v string) Option {
+	return func(o *options) {
+		o.zapOptions = append(o.zapOptions, zap.Fields(zap.String("log_schema", v)))
+	}
+}
+
+// WithLevel sets the minimum level of a logger built by NewWithOptions.
+// The default is InfoLevel.
+func WithLevel(level zapcore.Level) Option {
+	return func(o *options) {
+		o.level = level
+	}
+}
+
+// WithPrefix sets the name of a logger built by NewWithOptions. The default is none.
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithFile makes a logger built by NewWithOptions also write to f, as the logFile
+// argument of New does. The default, or a nil f, logs to stdout only.
+func WithFile(f *os.File) Option {
+	return func(o *options) {
+		o.file = f
+	}
+}
+
+// WithCaller annotates every entry with the file and line that called the Logger
+// method, in a caller field. It applies to loggers built by New, NewJSON,
+// NewWithOptions, NewWithSink and NewWithPath, and is off by default since finding
+// the caller costs time on every entry.
+func WithCaller(enabled bool) Option {
+	return func(o *options) {
+		o.caller = enabled
+	}
+}
+
+// WithJSON makes the logger start in JSON rather than console format, as NewJSON
+// does. The default is console output.
+func WithJSON(enabled bool) Option {
+	return func(o *options) {
+		o.json = enabled
+	}
+}
+
+// WithWriter makes a logger built by New, NewJSON or NewWithOptions tee its output
+// to w alongside stdout, as the logFile argument of New does for a file, so it can
+// log to a bytes.Buffer in tests, a network connection or a syslog writer. Writes
+// are serialized, so w need not be safe for concurrent use; a w that implements
+// zapcore.WriteSyncer is synced with the logger. It takes the place of logFile and
+// WithFile, and is itself replaced by WithRotatingFile. A nil w changes nothing.
+func WithWriter(w io.Writer) Option {
+	return func(o *options) {
+		if isNil(w) {
+			return
+		}
+		ws, ok := w.(zapcore.WriteSyncer)
+		if !ok {
+			ws = zapcore.AddSync(w)
+		}
+		o.writer = ws
+	}
+}
@@ -0,0 +1,81 @@
+package trace
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithCaller(t *testing.T) {
+	defer Reset()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := NewWithCore(core, "", WithCaller(true))
+	SetDefaultLogger(logger)
+
+	logger.Info("method")
+	Info("package-level")
+	Infof("package-level %s", "printf")
+
+	entries := logs.AllUntimed()
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	for _, e := range entries {
+		if !e.Caller.Defined {
+			t.Errorf("%q: caller not set", e.Message)
+			continue
+		}
+		if got := filepath.Base(e.Caller.File); got != "options_test.go" {
+			t.Errorf("%q: caller file = %s, want options_test.go", e.Message, got)
+		}
+	}
+}
+
+func TestWithCallerDisabled(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	NewWithCore(core, "").Info("no caller")
+
+	if e := logs.AllUntimed()[0]; e.Caller.Defined {
+		t.Errorf("caller = %s, want none by default", e.Caller)
+	}
+}
+
+func TestWithWriter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithOptions(WithWriter(&buf), WithLevel(zapcore.DebugLevel))
+	logger.Debug("to buffer", zap.String("key", "value"))
+
+	out := buf.String()
+	for _, want := range []string{"DEBUG", "to buffer", `"key": "value"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output %q does not contain %q", out, want)
+		}
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("writer output contains ANSI escapes: %q", out)
+	}
+}
+
+// BenchmarkWithCaller measures what WithCaller adds to every entry.
+func BenchmarkWithCaller(b *testing.B) {
+	for _, enabled := range []bool{false, true} {
+		name := "NoCaller"
+		if enabled {
+			name = "Caller"
+		}
+		b.Run(name, func(b *testing.B) {
+			logger := newBenchLogger(WithCaller(enabled))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				logger.Info("benchmark")
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package trace
+
+import (
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithOrderedSinks serializes writes across all of a logger's sinks (stdout, the
+// secondary file or sink, and any WithNameRoute sinks) under one lock, so every
+// entry is written to each sink before the next entry is written to any of them.
+// Concurrent goroutines then produce the same sequence of entries in every sink,
+// which keeps stdout and file output correlatable line by line.
+//
+// Without it, each sink is still locked per write, so entries never interleave
+// within a line, but two concurrent entries may reach the sinks in different orders.
+// The shared lock is held while encoding and writing to every sink, so throughput is
+// bounded by the slowest sink and concurrent loggers contend on it; combine it with
+// WithBuffer when a sink is slow. Loggers built by NewWithCore are not affected.
+func WithOrderedSinks() Option {
+	return func(o *options) {
+		o.orderedSinks = true
+	}
+}
+
+// orderedCore writes each entry to all of its inner cores while holding a lock
+// shared by every core derived from it.
+type orderedCore struct {
+	zapcore.Core
+	mu *sync.Mutex
+}
+
+func (c *orderedCore) With(fields []zapcore.Field) zapcore.Core {
+	return &orderedCore{Core: c.Core.With(fields), mu: c.mu}
+}
+
+func (c *orderedCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *orderedCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	writeChecked(c.Core, ent, fields)
+	return nil
+}
@@ -0,0 +1,219 @@
+// Package otlp bridges trace loggers to OpenTelemetry logs.
+//
+// It provides a zapcore.Core that converts every entry into an OpenTelemetry
+// log record and emits it through a log.LoggerProvider, typically an
+// sdk/log.LoggerProvider configured with an OTLP exporter. The package is kept
+// separate so the otel dependency is only pulled in by programs that use it.
+//
+//	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)))
+//	core := otlp.NewCore(provider, "my-app", zapcore.InfoLevel)
+//	zl := logger.Zap().WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+//		return zapcore.NewTee(c, core)
+//	}))
+package otlp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.uber.org/zap/zapcore"
+)
+
+var _ zapcore.Core = &Core{}
+
+// Core is a zapcore.Core that emits entries as OpenTelemetry log records.
+type Core struct {
+	zapcore.LevelEnabler
+	provider log.LoggerProvider
+	logger   log.Logger
+	fields   []zapcore.Field // fields bound via With
+}
+
+// NewCore returns a Core that emits entries at or above enab through an
+// otel logger obtained from provider under the given instrumentation name.
+func NewCore(provider log.LoggerProvider, name string, enab zapcore.LevelEnabler, opts ...log.LoggerOption) *Core {
+	return &Core{
+		LevelEnabler: enab,
+		provider:     provider,
+		logger:       provider.Logger(name, opts...),
+	}
+}
+
+// With returns a copy of the core with additional fields bound to every record.
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	clone.fields = append(clone.fields, c.fields...)
+	clone.fields = append(clone.fields, fields...)
+	return &clone
+}
+
+// Check adds the core to the checked entry if the level is enabled.
+func (c *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write converts the entry into a log record and emits it.
+// A field holding a context.Context (e.g. zap.Any("ctx", ctx)) is used as the
+// emit context, so the SDK can correlate the record with the active span,
+// instead of being recorded as an attribute.
+func (c *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ctx := context.Background()
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		ctx = addField(ctx, enc, f)
+	}
+	for _, f := range fields {
+		ctx = addField(ctx, enc, f)
+	}
+
+	var r log.Record
+	r.SetTimestamp(ent.Time)
+	r.SetBody(attribute.StringValue(ent.Message))
+	r.SetSeverity(severity(ent.Level))
+	r.SetSeverityText(ent.Level.CapitalString())
+
+	if ent.LoggerName != "" {
+		r.AddAttributes(attribute.String("logger", ent.LoggerName))
+	}
+	if ent.Caller.Defined {
+		r.AddAttributes(
+			attribute.String("code.filepath", ent.Caller.File),
+			attribute.Int("code.lineno", ent.Caller.Line),
+		)
+		if ent.Caller.Function != "" {
+			r.AddAttributes(attribute.String("code.function", ent.Caller.Function))
+		}
+	}
+	if ent.Stack != "" {
+		r.AddAttributes(attribute.String("code.stacktrace", ent.Stack))
+	}
+	r.AddAttributes(toKeyValues(enc.Fields)...)
+
+	c.logger.Emit(ctx, r)
+	return nil
+}
+
+// Sync flushes the provider if it supports ForceFlush (as the SDK provider does).
+func (c *Core) Sync() error {
+	if f, ok := c.provider.(interface{ ForceFlush(context.Context) error }); ok {
+		return f.ForceFlush(context.Background())
+	}
+	return nil
+}
+
+func addField(ctx context.Context, enc *zapcore.MapObjectEncoder, f zapcore.Field) context.Context {
+	if fctx, ok := f.Interface.(context.Context); ok {
+		return fctx
+	}
+	f.AddTo(enc)
+	return ctx
+}
+
+// severity maps zap levels onto the OpenTelemetry severity scale.
+func severity(level zapcore.Level) log.Severity {
+	switch level {
+	case zapcore.DebugLevel:
+		return log.SeverityDebug
+	case zapcore.InfoLevel:
+		return log.SeverityInfo
+	case zapcore.WarnLevel:
+		return log.SeverityWarn
+	case zapcore.ErrorLevel:
+		return log.SeverityError
+	case zapcore.DPanicLevel:
+		return log.SeverityFatal1
+	case zapcore.PanicLevel:
+		return log.SeverityFatal2
+	case zapcore.FatalLevel:
+		return log.SeverityFatal3
+	default:
+		return log.SeverityUndefined
+	}
+}
+
+// toKeyValues converts encoded fields into attributes with deterministic ordering.
+func toKeyValues(m map[string]interface{}) []attribute.KeyValue {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]attribute.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, attribute.KeyValue{Key: attribute.Key(k), Value: toValue(m[k])})
+	}
+	return kvs
+}
+
+func toValue(v interface{}) attribute.Value {
+	switch v := v.(type) {
+	case nil:
+		return attribute.StringValue("<nil>")
+	case bool:
+		return attribute.BoolValue(v)
+	case string:
+		return attribute.StringValue(v)
+	case []byte:
+		return attribute.ByteSliceValue(v)
+	case int:
+		return attribute.IntValue(v)
+	case int8:
+		return attribute.Int64Value(int64(v))
+	case int16:
+		return attribute.Int64Value(int64(v))
+	case int32:
+		return attribute.Int64Value(int64(v))
+	case int64:
+		return attribute.Int64Value(v)
+	case uint:
+		return uintValue(uint64(v))
+	case uint8:
+		return attribute.Int64Value(int64(v))
+	case uint16:
+		return attribute.Int64Value(int64(v))
+	case uint32:
+		return attribute.Int64Value(int64(v))
+	case uint64:
+		return uintValue(v)
+	case uintptr:
+		return uintValue(uint64(v))
+	case float32:
+		return attribute.Float64Value(float64(v))
+	case float64:
+		return attribute.Float64Value(v)
+	case time.Time:
+		return attribute.StringValue(v.Format(time.RFC3339Nano))
+	case time.Duration:
+		return attribute.StringValue(v.String())
+	case []interface{}:
+		vals := make([]attribute.Value, len(v))
+		for i, e := range v {
+			vals[i] = toValue(e)
+		}
+		return attribute.SliceValue(vals...)
+	case map[string]interface{}:
+		return attribute.MapValue(toKeyValues(v)...)
+	case fmt.Stringer:
+		return attribute.StringValue(v.String())
+	default:
+		return attribute.StringValue(fmt.Sprint(v))
+	}
+}
+
+// uintValue keeps values that overflow int64 as their decimal string.
+func uintValue(v uint64) attribute.Value {
+	if v > math.MaxInt64 {
+		return attribute.StringValue(fmt.Sprint(v))
+	}
+	return attribute.Int64Value(int64(v))
+}
@@ -0,0 +1,75 @@
+package trace
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime/debug"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// panicMonitorEnv marks the monitor process started by InstallPanicLogger.
+const panicMonitorEnv = "TRACE_PANIC_MONITOR"
+
+// InstallPanicLogger makes unrecovered panics and fatal runtime errors in any goroutine
+// produce a structured entry on logger, with the panic value in "panic" and the
+// goroutine dump in "stack", before the process dies.
+//
+// A crashing process cannot log reliably itself, so InstallPanicLogger re-executes the
+// program as a monitor process and points the runtime's crash output at it with
+// debug.SetCrashOutput; the monitor logs the crash report when one arrives and exits
+// silently otherwise. The call must come first in main: in the monitor it logs and
+// exits without returning, so any code before it runs twice.
+//
+// Limits: it needs os.Executable and process creation, so it returns an error on
+// platforms without them (js/wasm, wasip1); crash reports are still printed to
+// stderr as usual. Recovered panics, os.Exit and external kills (including the
+// out-of-memory killer) are not reported.
+func InstallPanicLogger(logger Logger) error {
+	if os.Getenv(panicMonitorEnv) != "" {
+		monitorCrash(logger, os.Stdin)
+		os.Exit(0)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("trace: install panic logger: %w", err)
+	}
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Env = append(os.Environ(), panicMonitorEnv+"=1")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("trace: install panic logger: %w", err)
+	}
+	defer w.Close() // SetCrashOutput keeps its own duplicate of the descriptor
+	cmd.Stdin = r
+	err = cmd.Start()
+	r.Close()
+	if err != nil {
+		return fmt.Errorf("trace: install panic logger: %w", err)
+	}
+	if err := debug.SetCrashOutput(w, debug.CrashOptions{}); err != nil {
+		return fmt.Errorf("trace: install panic logger: %w", err)
+	}
+	return nil
+}
+
+// monitorCrash reads a crash report from r and logs it, doing nothing if r is empty.
+func monitorCrash(logger Logger, r io.Reader) {
+	data, err := io.ReadAll(r)
+	if err != nil || len(data) == 0 {
+		return
+	}
+	report := strings.TrimSpace(string(data))
+	head, stack, _ := strings.Cut(report, "\n")
+	logger.Error("unrecovered panic",
+		zap.String("panic", head),
+		zap.String("stack", strings.TrimSpace(stack)),
+	)
+	_ = logger.Zap().Sync()
+}
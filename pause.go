@@ -0,0 +1,111 @@
+package trace
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// PauseBehavior decides what happens to entries logged while logging is paused.
+type PauseBehavior int32
+
+const (
+	// DropWhilePaused is the default: entries logged while paused are discarded.
+	DropWhilePaused PauseBehavior = iota
+	// BufferWhilePaused keeps entries logged while paused, up to maxPausedEntries,
+	// and writes them in order on Resume. Entries beyond the limit are dropped.
+	BufferWhilePaused
+)
+
+// maxPausedEntries bounds how many entries BufferWhilePaused keeps.
+const maxPausedEntries = 10000
+
+var (
+	paused        atomic.Bool
+	pauseBehavior atomic.Int32
+
+	pauseMu      sync.Mutex
+	pausedWrites []pausedEntry // guarded by pauseMu
+)
+
+// pausedEntry is an entry held back by BufferWhilePaused with the core to write it to.
+type pausedEntry struct {
+	core   zapcore.Core
+	ent    zapcore.Entry
+	fields []zapcore.Field
+}
+
+// Pause halts output of every logger built by this package until Resume is called,
+// for short moments that need the terminal to themselves, such as a CLI prompt.
+// Unlike a level change it affects all levels alike, except that DPanic, Panic and
+// Fatal entries are always written. What happens to the entries logged meanwhile
+// is set by SetPauseBehavior. Pausing twice needs only one Resume.
+func Pause() {
+	pauseMu.Lock()
+	paused.Store(true)
+	pauseMu.Unlock()
+}
+
+// Resume restarts output after Pause, first writing any buffered entries in the
+// order they were logged. Fields are encoded only now, so values they reference
+// should not be modified while paused.
+func Resume() {
+	pauseMu.Lock()
+	defer pauseMu.Unlock()
+	for _, p := range pausedWrites {
+		writeChecked(p.core, p.ent, p.fields)
+	}
+	pausedWrites = nil
+	paused.Store(false)
+}
+
+// SetPauseBehavior sets what happens to entries logged while paused.
+func SetPauseBehavior(b PauseBehavior) {
+	pauseBehavior.Store(int32(b))
+}
+
+func resetPause() {
+	pauseMu.Lock()
+	paused.Store(false)
+	pausedWrites = nil
+	pauseMu.Unlock()
+	pauseBehavior.Store(int32(DropWhilePaused))
+}
+
+// pauseCore holds back entries while logging is paused.
+type pauseCore struct {
+	zapcore.Core
+}
+
+func (c *pauseCore) With(fields []zapcore.Field) zapcore.Core {
+	return &pauseCore{Core: c.Core.With(fields)}
+}
+
+func (c *pauseCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !paused.Load() || ent.Level > zapcore.ErrorLevel {
+		return c.Core.Check(ent, ce)
+	}
+	if PauseBehavior(pauseBehavior.Load()) == DropWhilePaused {
+		return ce
+	}
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write is reached only for entries checked while paused with BufferWhilePaused.
+func (c *pauseCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	pauseMu.Lock()
+	if paused.Load() {
+		if len(pausedWrites) < maxPausedEntries {
+			pausedWrites = append(pausedWrites, pausedEntry{core: c.Core, ent: ent, fields: appendFields(nil, fields)})
+		}
+		pauseMu.Unlock()
+		return nil
+	}
+	pauseMu.Unlock()
+	writeChecked(c.Core, ent, fields)
+	return nil
+}
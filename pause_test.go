@@ -0,0 +1,50 @@
+package trace
+
+import (
+	"slices"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestPauseDrop(t *testing.T) {
+	defer Reset()
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := NewWithCore(core, "")
+
+	Pause()
+	Pause()
+	logger.Info("dropped")
+	logger.Error("dropped too")
+	logger.Log(zapcore.DPanicLevel, "always written")
+	Resume()
+	logger.Info("after resume")
+
+	if got := messages(logs); !slices.Equal(got, []string{"always written", "after resume"}) {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestPauseBuffer(t *testing.T) {
+	defer Reset()
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := NewWithCore(core, "")
+	SetPauseBehavior(BufferWhilePaused)
+
+	Pause()
+	logger.Info("first")
+	logger.With(Int("n", 2)).Warn("second")
+	logger.Debug("third")
+	if logs.Len() != 0 {
+		t.Fatalf("got %d entries while paused, want 0", logs.Len())
+	}
+	Resume()
+
+	if got := messages(logs); !slices.Equal(got, []string{"first", "second", "third"}) {
+		t.Errorf("after resume got %q, want buffered entries in order", got)
+	}
+	if m := logs.AllUntimed()[1].ContextMap(); m["n"] != int64(2) {
+		t.Errorf("buffered entry fields = %v, want n=2", m)
+	}
+}
@@ -0,0 +1,32 @@
+package trace
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Percent logs part as a percentage of total, as a nested object holding the
+// percent (a float) and the raw part and total:
+//
+//	logger.Info("import progress", trace.Percent("rows", done, all))
+//	// {"rows": {"percent": 42.5, "part": 425, "total": 1000}}
+//
+// A zero total yields a percent of 0.
+func Percent(key string, part, total int64) zap.Field {
+	return zap.Object(key, percent{part: part, total: total})
+}
+
+type percent struct {
+	part, total int64
+}
+
+func (p percent) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	pct := 0.0
+	if p.total != 0 {
+		pct = float64(p.part) / float64(p.total) * 100
+	}
+	enc.AddFloat64("percent", pct)
+	enc.AddInt64("part", p.part)
+	enc.AddInt64("total", p.total)
+	return nil
+}
@@ -0,0 +1,28 @@
+package trace
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		name        string
+		part, total int64
+		want        float64
+	}{
+		{"fraction", 425, 1000, 42.5},
+		{"complete", 7, 7, 100},
+		{"zero total", 5, 0, 0},
+		{"zero part and total", 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FieldsToMap(Percent("rows", tt.part, tt.total))
+			want := map[string]interface{}{"rows": map[string]interface{}{"percent": tt.want, "part": tt.part, "total": tt.total}}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Percent(%d, %d) = %v, want %v", tt.part, tt.total, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package trace
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// maxPooledFields bounds the capacity of builders returned to the pool, so one
+// unusually large entry does not pin a large buffer.
+const maxPooledFields = 64
+
+var fieldsPool = sync.Pool{
+	New: func() interface{} { return &Fields{fields: make([]zap.Field, 0, 16)} },
+}
+
+// Fields is a pooled field buffer for the hottest logging paths, avoiding the slice
+// allocated per call by the variadic API:
+//
+//	trace.AcquireFields().Str("path", path).Int("status", code).LogAndRelease(logger, trace.InfoLevel, "request")
+//
+// A Fields must not be used after LogAndRelease or Release.
+type Fields struct {
+	fields []zap.Field
+}
+
+// AcquireFields returns an empty Fields from the pool.
+func AcquireFields() *Fields {
+	return fieldsPool.Get().(*Fields)
+}
+
+// Field appends f.
+func (b *Fields) Field(f zap.Field) *Fields {
+	b.fields = append(b.fields, f)
+	return b
+}
+
+// Str appends a string field.
+func (b *Fields) Str(key, val string) *Fields { return b.Field(zap.String(key, val)) }
+
+// Int appends an int field.
+func (b *Fields) Int(key string, val int) *Fields { return b.Field(zap.Int(key, val)) }
+
+// Int64 appends an int64 field.
+func (b *Fields) Int64(key string, val int64) *Fields { return b.Field(zap.Int64(key, val)) }
+
+// Bool appends a bool field.
+func (b *Fields) Bool(key string, val bool) *Fields { return b.Field(zap.Bool(key, val)) }
+
+// Float64 appends a float64 field.
+func (b *Fields) Float64(key string, val float64) *Fields { return b.Field(zap.Float64(key, val)) }
+
+// Dur appends a duration field.
+func (b *Fields) Dur(key string, val time.Duration) *Fields { return b.Field(zap.Duration(key, val)) }
+
+// Time appends a time field.
+func (b *Fields) Time(key string, val time.Time) *Fields { return b.Field(zap.Time(key, val)) }
+
+// Err appends err as by Err.
+func (b *Fields) Err(err error) *Fields { return b.Field(Err(err)) }
+
+// Any appends a field as by Any.
+func (b *Fields) Any(key string, val interface{}) *Fields { return b.Field(Any(key, val)) }
+
+// LogAndRelease logs msg at level with the collected fields and returns b to the pool.
+// Under WithCaller the reported caller is the code calling LogAndRelease.
+func (b *Fields) LogAndRelease(logger Logger, level zapcore.Level, msg string) {
+	if sl, ok := logger.(*sugarLogger); ok {
+		sl.logSkip(1, level, msg, b.fields...)
+	} else {
+		logger.Log(level, msg, b.fields...)
+	}
+	b.Release()
+}
+
+// Release returns b to the pool without logging.
+func (b *Fields) Release() {
+	if cap(b.fields) > maxPooledFields {
+		return
+	}
+	clear(b.fields)
+	b.fields = b.fields[:0]
+	fieldsPool.Put(b)
+}
@@ -0,0 +1,57 @@
+package trace
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogAndRelease(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := NewWithCore(core, "", WithCaller(true))
+
+	AcquireFields().Str("path", "/users").Int("status", 200).LogAndRelease(logger, zapcore.WarnLevel, "request")
+
+	entries := logs.AllUntimed()
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.Level != zapcore.WarnLevel || e.Message != "request" {
+		t.Errorf("entry = %s %q, want warn \"request\"", e.Level, e.Message)
+	}
+	if m := e.ContextMap(); m["path"] != "/users" || m["status"] != int64(200) {
+		t.Errorf("fields = %v, want path=/users status=200", m)
+	}
+	if got := filepath.Base(e.Caller.File); got != "pool_test.go" {
+		t.Errorf("caller file = %s, want pool_test.go", got)
+	}
+}
+
+// BenchmarkLogAndRelease compares pooled fields with the variadic API.
+func BenchmarkLogAndRelease(b *testing.B) {
+	b.Run("Pooled", func(b *testing.B) {
+		logger := newBenchLogger()
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				AcquireFields().Str("path", "/users").Int("status", 200).Bool("cached", true).
+					LogAndRelease(logger, zapcore.InfoLevel, "request")
+			}
+		})
+	})
+
+	b.Run("Variadic", func(b *testing.B) {
+		logger := newBenchLogger()
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				logger.Info("request", Str("path", "/users"), Int("status", 200), Bool("cached", true))
+			}
+		})
+	})
+}
@@ -0,0 +1,65 @@
+package trace
+
+// Printf-style logging, for code migrating from the standard library's log package.
+// The message is formatted with fmt.Sprintf only when the level is enabled; prefer
+// the structured methods, whose fields stay queryable, for new code.
+
+// Debugf logs a formatted debug message.
+func (l *sugarLogger) Debugf(template string, args ...interface{}) {
+	if l != nil && l.log != nil {
+		l.log.Sugar().Debugf(template, args...)
+	}
+}
+
+// Infof logs a formatted info message.
+func (l *sugarLogger) Infof(template string, args ...interface{}) {
+	if l != nil && l.log != nil {
+		l.log.Sugar().Infof(template, args...)
+	}
+}
+
+// Warnf logs a formatted warning message.
+func (l *sugarLogger) Warnf(template string, args ...interface{}) {
+	if l != nil && l.log != nil {
+		l.log.Sugar().Warnf(template, args...)
+	}
+}
+
+// Errorf logs a formatted error message.
+func (l *sugarLogger) Errorf(template string, args ...interface{}) {
+	if l != nil && l.log != nil {
+		l.log.Sugar().Errorf(template, args...)
+	}
+}
+
+// Fatalf logs a formatted fatal message and exits.
+func (l *sugarLogger) Fatalf(template string, args ...interface{}) {
+	if l != nil && l.log != nil {
+		l.log.Sugar().Fatalf(template, args...)
+	}
+}
+
+// Debugf logs a formatted debug message with the default logger.
+func Debugf(template string, args ...interface{}) {
+	defaultForCaller().Debugf(template, args...)
+}
+
+// Infof logs a formatted info message with the default logger.
+func Infof(template string, args ...interface{}) {
+	defaultForCaller().Infof(template, args...)
+}
+
+// Warnf logs a formatted warning message with the default logger.
+func Warnf(template string, args ...interface{}) {
+	defaultForCaller().Warnf(template, args...)
+}
+
+// Errorf logs a formatted error message with the default logger.
+func Errorf(template string, args ...interface{}) {
+	defaultForCaller().Errorf(template, args...)
+}
+
+// Fatalf logs a formatted fatal message with the default logger and exits.
+func Fatalf(template string, args ...interface{}) {
+	defaultForCaller().Fatalf(template, args...)
+}
@@ -0,0 +1,55 @@
+package trace
+
+import (
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestInfof(t *testing.T) {
+	logger, lines := NewMemory()
+	logger.Infof("count=%d", 5)
+
+	got := lines()
+	if len(got) != 1 {
+		t.Fatalf("got %d lines, want 1", len(got))
+	}
+	if !strings.Contains(got[0], "INFO") || !strings.Contains(got[0], "count=5") {
+		t.Errorf("line = %q, want INFO entry with count=5", got[0])
+	}
+}
+
+// countingStringer records whether it was formatted.
+type countingStringer struct{ calls *int }
+
+func (s countingStringer) String() string {
+	*s.calls++
+	return "formatted"
+}
+
+func TestPrintfDisabledLevelSkipsFormatting(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := NewWithCore(core, "")
+
+	var calls int
+	logger.Debugf("value=%s", countingStringer{&calls})
+
+	if calls != 0 {
+		t.Errorf("argument formatted %d times for a disabled level, want 0", calls)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("got %d entries, want 0", logs.Len())
+	}
+}
+
+func TestNoopPrintf(t *testing.T) {
+	for _, logger := range []Logger{NewNoopLogger(), &sugarLogger{}} {
+		logger.Debugf("debug %d", 1)
+		logger.Infof("info %d", 2)
+		logger.Warnf("warn %d", 3)
+		logger.Errorf("error %d", 4)
+		logger.Fatalf("fatal %d", 5)
+	}
+}
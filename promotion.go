@@ -0,0 +1,96 @@
+package trace
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// maxPromotionKeys bounds how many distinct warnings are tracked before expired ones are pruned.
+const maxPromotionKeys = 1024
+
+// WithPromotion promotes recurring warnings to Error so they trigger alerting.
+// Warn entries accepted by match (all of them when match is nil) are counted per
+// message; once the same message is seen more than threshold times within window,
+// it and further occurrences in that window are written at Error level with a
+// promoted_from field.
+func WithPromotion(match func(zapcore.Entry) bool, threshold int, window time.Duration) Option {
+	state := &promotionState{
+		match:     match,
+		threshold: threshold,
+		window:    window,
+		seen:      make(map[string]*promotionCount),
+	}
+	return wrapCore(func(core zapcore.Core) zapcore.Core {
+		return &promotionCore{Core: core, state: state}
+	})
+}
+
+type promotionCount struct {
+	start time.Time
+	n     int
+}
+
+type promotionState struct {
+	match     func(zapcore.Entry) bool
+	threshold int
+	window    time.Duration
+
+	mu   sync.Mutex
+	seen map[string]*promotionCount
+}
+
+// promote records an occurrence of ent and reports whether it crossed the threshold.
+func (s *promotionState) promote(ent zapcore.Entry) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.seen[ent.Message]
+	if !ok || ent.Time.Sub(c.start) > s.window {
+		if !ok && len(s.seen) >= maxPromotionKeys {
+			s.prune(ent.Time)
+		}
+		c = &promotionCount{start: ent.Time}
+		s.seen[ent.Message] = c
+	}
+	c.n++
+	return c.n > s.threshold
+}
+
+func (s *promotionState) prune(now time.Time) {
+	for msg, c := range s.seen {
+		if now.Sub(c.start) > s.window {
+			delete(s.seen, msg)
+		}
+	}
+}
+
+type promotionCore struct {
+	zapcore.Core
+	state *promotionState
+}
+
+func (c *promotionCore) With(fields []zapcore.Field) zapcore.Core {
+	return &promotionCore{Core: c.Core.With(fields), state: c.state}
+}
+
+func (c *promotionCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level != zapcore.WarnLevel || (c.state.match != nil && !c.state.match(ent)) {
+		return c.Core.Check(ent, ce)
+	}
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *promotionCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if c.state.promote(ent) {
+		ent.Level = zapcore.ErrorLevel
+		fields = appendFields(fields, []zapcore.Field{zap.String("promoted_from", zapcore.WarnLevel.String())})
+	}
+	writeChecked(c.Core, ent, fields)
+	return nil
+}
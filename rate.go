@@ -0,0 +1,57 @@
+package trace
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// rateWindow is the number of one-second buckets Rate averages over.
+const rateWindow = 10
+
+// rateCounter counts entries in a ring of one-second buckets without locks.
+// A bucket is reset by the first increment that finds it holding an older second;
+// increments racing with that reset may be lost, which is acceptable for monitoring.
+type rateCounter struct {
+	buckets [rateWindow]rateBucket
+}
+
+type rateBucket struct {
+	sec   atomic.Int64
+	count atomic.Int64
+}
+
+// observe is a zap hook counting every entry written.
+func (r *rateCounter) observe(zapcore.Entry) error {
+	sec := time.Now().Unix()
+	b := &r.buckets[sec%rateWindow]
+	if old := b.sec.Load(); old != sec && b.sec.CompareAndSwap(old, sec) {
+		b.count.Store(0)
+	}
+	b.count.Add(1)
+	return nil
+}
+
+// perSecond averages the entries of the last rateWindow complete seconds.
+func (r *rateCounter) perSecond() float64 {
+	now := time.Now().Unix()
+	var total int64
+	for i := range r.buckets {
+		b := &r.buckets[i]
+		if sec := b.sec.Load(); sec < now && sec >= now-rateWindow {
+			total += b.count.Load()
+		}
+	}
+	return float64(total) / rateWindow
+}
+
+// Rate returns the average number of entries per second written over the last ten
+// seconds by the logger and every logger sharing its root, for detecting runaway
+// logging. Entries dropped by level or silencing are not counted.
+func (l *sugarLogger) Rate() (perSecond float64) {
+	if l == nil || l.cfg == nil || l.cfg.rate == nil {
+		return 0
+	}
+	return l.cfg.rate.perSecond()
+}
@@ -0,0 +1,29 @@
+package trace
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RateLimit returns the standard fields describing a client's rate-limit state, as
+// read from headers such as X-RateLimit-* and Retry-After: ratelimit_limit,
+// ratelimit_remaining, ratelimit_reset and retry_after. Absent values are omitted:
+// a non-positive limit, a negative remaining, a zero reset and a non-positive
+// retryAfter. The result is empty when nothing is known.
+func RateLimit(limit, remaining int, reset time.Time, retryAfter time.Duration) []zap.Field {
+	fields := make([]zap.Field, 0, 4)
+	if limit > 0 {
+		fields = append(fields, zap.Int("ratelimit_limit", limit))
+	}
+	if remaining >= 0 {
+		fields = append(fields, zap.Int("ratelimit_remaining", remaining))
+	}
+	if !reset.IsZero() {
+		fields = append(fields, zap.Time("ratelimit_reset", reset))
+	}
+	if retryAfter > 0 {
+		fields = append(fields, zap.Duration("retry_after", retryAfter))
+	}
+	return fields
+}
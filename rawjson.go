@@ -0,0 +1,32 @@
+package trace
+
+import (
+	"encoding/json"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RawJSON embeds already serialized JSON, such as an upstream response body, as a
+// nested value rather than an escaped string. If data is not valid JSON it is logged
+// as a string instead, with key_invalid_json set to true. Empty data logs null.
+func RawJSON(key string, data []byte) zap.Field {
+	if len(data) == 0 {
+		return zap.Reflect(key, json.RawMessage("null"))
+	}
+	if !json.Valid(data) {
+		return zap.Inline(invalidJSON{key: key, data: data})
+	}
+	return zap.Reflect(key, json.RawMessage(data))
+}
+
+type invalidJSON struct {
+	key  string
+	data []byte
+}
+
+func (j invalidJSON) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddByteString(j.key, j.data)
+	enc.AddBool(j.key+"_invalid_json", true)
+	return nil
+}
@@ -0,0 +1,78 @@
+package trace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+)
+
+// RecoveryMiddleware returns HTTP middleware that recovers panics in the wrapped
+// handler, logs them at Error with the request method, path and remote address,
+// the panic value and the stack, and responds with 500 Internal Server Error if
+// nothing has been written yet:
+//
+//	http.ListenAndServe(addr, trace.RecoveryMiddleware(logger)(mux))
+//
+// http.ErrAbortHandler is re-panicked unlogged, so net/http can abort the response
+// as intended. The panic is logged on the request-scoped logger attached to the
+// request's context with ContextWithLogger, if there is one, so it carries the
+// request's fields, and on logger otherwise. A nil logger uses the default logger
+// at the time of the panic.
+func RecoveryMiddleware(logger Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := &recoveryWriter{ResponseWriter: w}
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				if err, ok := p.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(p)
+				}
+
+				l, ok := contextLogger(r.Context())
+				if !ok {
+					l = logger
+				}
+				if isNil(l) {
+					l = GetDefaultLogger()
+				}
+				l.Error("panic in http handler",
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.String("remote_addr", r.RemoteAddr),
+					zap.String("panic", fmt.Sprint(p)),
+					zap.ByteString("stack", debug.Stack()),
+				)
+				if !rw.wrote {
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+			}()
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// recoveryWriter records whether a response has been started, since the status
+// can no longer be changed afterwards.
+type recoveryWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (w *recoveryWriter) WriteHeader(code int) {
+	w.wrote = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recoveryWriter) Write(p []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(p)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *recoveryWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
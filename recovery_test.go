@@ -0,0 +1,95 @@
+package trace
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	base := NewWithCore(core, "")
+	ctxLogger := base.With(Str("request_id", "r1"))
+
+	handler := RecoveryMiddleware(base)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+	req = req.WithContext(ContextWithLogger(req.Context(), ctxLogger))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+	entries := logs.AllUntimed()
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	e := entries[0]
+	m := e.ContextMap()
+	if e.Level != zapcore.ErrorLevel || m["method"] != "POST" || m["path"] != "/orders" || m["panic"] != "boom" {
+		t.Errorf("entry = %s %v, want error with method, path and panic", e.Level, m)
+	}
+	if m["request_id"] != "r1" {
+		t.Errorf("request_id = %v, want it from the context logger", m["request_id"])
+	}
+	if stack, _ := m["stack"].(string); !strings.Contains(stack, "recovery_test.go") {
+		t.Errorf("stack does not include the panicking handler:\n%s", stack)
+	}
+}
+
+func TestRecoveryMiddlewareAfterWriteHeader(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	handler := RecoveryMiddleware(NewWithCore(core, ""))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		panic("late")
+	}))
+	rec := &headerCounter{ResponseRecorder: httptest.NewRecorder()}
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.headers != 1 {
+		t.Errorf("WriteHeader called %d times, want 1", rec.headers)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want the 200 already written", rec.Code)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("body = %q, want no error response after the header", rec.Body.String())
+	}
+	if logs.Len() != 1 {
+		t.Errorf("got %d entries, want 1", logs.Len())
+	}
+}
+
+func TestRecoveryMiddlewareAbortHandler(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	handler := RecoveryMiddleware(NewWithCore(core, ""))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if p := recover(); p != http.ErrAbortHandler {
+			t.Errorf("recovered %v, want http.ErrAbortHandler re-panicked", p)
+		}
+		if logs.Len() != 0 {
+			t.Errorf("got %d entries, want ErrAbortHandler unlogged", logs.Len())
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+// headerCounter counts WriteHeader calls, which httptest.ResponseRecorder ignores after the first.
+type headerCounter struct {
+	*httptest.ResponseRecorder
+	headers int
+}
+
+func (w *headerCounter) WriteHeader(code int) {
+	w.headers++
+	w.ResponseRecorder.WriteHeader(code)
+}
@@ -0,0 +1,26 @@
+package trace
+
+import "sync"
+
+// registry holds the loggers registered by Register, keyed by module name.
+var registry sync.Map // string -> Logger
+
+// Register makes l the logger returned by Named(name), so the modules of a larger
+// program can each fetch their own configured logger without passing it around.
+// Registering a nil logger removes the name.
+func Register(name string, l Logger) {
+	if l == nil {
+		registry.Delete(name)
+		return
+	}
+	registry.Store(name, l)
+}
+
+// Named returns the logger registered under name, or the default logger if none is.
+// The lookup is done on every call, so later registrations are seen.
+func Named(name string) Logger {
+	if l, ok := registry.Load(name); ok {
+		return l.(Logger)
+	}
+	return GetDefaultLogger()
+}
@@ -0,0 +1,92 @@
+package trace
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewWithPath is like New but opens (or creates) the log file at path itself, in
+// append mode. Because the logger owns the file, it can reopen it after external
+// rotation; see ReopenOnSignal.
+func NewWithPath(level zapcore.Level, prefix, path string, opts ...Option) (Logger, error) {
+	f, err := openLogFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rf := &reopenableFile{path: path, f: f}
+	return newTeeLogger(level, prefix, rf, &loggerConfig{reopen: rf}, newOptions(opts)), nil
+}
+
+func openLogFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+}
+
+// ReopenOnSignal reopens the log file whenever sig is received, so logrotate's
+// move-then-signal scheme works: the old handle is closed and writing continues at
+// the original path. It only applies to loggers built by NewWithPath and is a no-op
+// otherwise. Call stop to release the signal handler and its goroutine.
+func (l *sugarLogger) ReopenOnSignal(sig os.Signal) (stop func()) {
+	if l == nil || l.log == nil || l.cfg == nil || l.cfg.reopen == nil {
+		return func() {}
+	}
+	rf := l.cfg.reopen
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sig)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ch:
+				if err := rf.reopen(); err != nil {
+					l.log.Error("failed to reopen log file", zap.String("path", rf.path), zap.Error(err))
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+		})
+	}
+}
+
+// reopenableFile is a file sink whose handle can be replaced while in use.
+type reopenableFile struct {
+	path string
+	mu   sync.Mutex
+	f    *os.File
+}
+
+func (r *reopenableFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.f.Write(p)
+}
+
+func (r *reopenableFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.f.Sync()
+}
+
+func (r *reopenableFile) reopen() error {
+	f, err := openLogFile(r.path)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	old := r.f
+	r.f = f
+	r.mu.Unlock()
+	return old.Close()
+}
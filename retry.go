@@ -0,0 +1,29 @@
+package trace
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RetryAttempt returns the standard fields describing one attempt of a retry loop:
+// retry_attempt, retry_max, error and retry_backoff (the wait before the next attempt),
+// so every client logs retries the same way:
+//
+//	logger.Warn("request failed, retrying", trace.RetryAttempt(i, max, err, backoff)...)
+//
+// A nil err marks the final, successful attempt and yields only retry_attempt and retry_max.
+func RetryAttempt(attempt, max int, err error, nextBackoff time.Duration) []zap.Field {
+	if err == nil {
+		return []zap.Field{
+			zap.Int("retry_attempt", attempt),
+			zap.Int("retry_max", max),
+		}
+	}
+	return []zap.Field{
+		zap.Int("retry_attempt", attempt),
+		zap.Int("retry_max", max),
+		Err(err),
+		zap.Duration("retry_backoff", nextBackoff),
+	}
+}
@@ -0,0 +1,31 @@
+package trace
+
+import (
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// RotateConfig configures WithRotatingFile. Zero values keep lumberjack's defaults:
+// 100 MB files, every backup kept for ever, and no compression.
+type RotateConfig struct {
+	MaxSizeMB  int  // size in megabytes at which the file is rotated
+	MaxBackups int  // number of rotated files to keep
+	MaxAgeDays int  // days to keep rotated files, based on the time in their name
+	Compress   bool // gzip rotated files
+}
+
+// WithRotatingFile makes a logger built by New, NewJSON or NewWithOptions write
+// its file output to path through a rotating writer instead of a single file, so
+// long-running daemons do not fill the disk. Output is still teed to stdout.
+// It replaces the logFile argument of New, WithFile and WithWriter. Rotated files
+// are named after path with a timestamp, such as app-2024-01-02T15-04-05.000.log.
+func WithRotatingFile(path string, cfg RotateConfig) Option {
+	return func(o *options) {
+		o.rotate = &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    cfg.MaxSizeMB,
+			MaxBackups: cfg.MaxBackups,
+			MaxAge:     cfg.MaxAgeDays,
+			Compress:   cfg.Compress,
+		}
+	}
+}
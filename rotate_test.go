@@ -0,0 +1,38 @@
+package trace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWithRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	// The entries are teed to stdout as well, so keep them out of the test output.
+	captureStdout(t, func() {
+		logger := NewWithOptions(WithRotatingFile(filepath.Join(dir, "app.log"), RotateConfig{MaxSizeMB: 1}))
+		line := strings.Repeat("x", 300<<10)
+		for i := 0; i < 8; i++ {
+			logger.Info(line)
+		}
+		_ = logger.Sync()
+	})
+
+	files, err := filepath.Glob(filepath.Join(dir, "app*.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) < 2 {
+		t.Fatalf("files = %q, want the log and at least one rotated backup", files)
+	}
+	for _, f := range files {
+		fi, err := os.Stat(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Size() > 1<<20 {
+			t.Errorf("%s: size %d, want at most 1 MB", f, fi.Size())
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package trace
+
+import (
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithNameRoute additionally writes entries of matching named loggers to sink, giving
+// per-component log files without separate loggers:
+//
+//	logger := trace.New(trace.InfoLevel, "", nil, trace.WithNameRoute("payments*", paymentsLog))
+//	logger.Named("payments").Info("charged") // stdout and payments.log
+//
+// match is compared with the full logger name (such as "app.payments"); it matches
+// exactly, or as a prefix when it ends in "*". Routed entries use the logger's level
+// and format. It applies to loggers built by New, NewJSON, NewWithSink and NewWithPath.
+func WithNameRoute(match string, sink zapcore.WriteSyncer) Option {
+	return func(o *options) {
+		if sink != nil {
+			o.routes = append(o.routes, nameRoute{match: match, sink: sink})
+		}
+	}
+}
+
+type nameRoute struct {
+	match string
+	sink  zapcore.WriteSyncer
+}
+
+func (r nameRoute) matches(name string) bool {
+	if prefix, ok := strings.CutSuffix(r.match, "*"); ok {
+		return strings.HasPrefix(name, prefix)
+	}
+	return name == r.match
+}
+
+// nameRouteCore passes entries to its sink core only for matching logger names.
+type nameRouteCore struct {
+	zapcore.Core
+	route nameRoute
+}
+
+func (c *nameRouteCore) With(fields []zapcore.Field) zapcore.Core {
+	return &nameRouteCore{Core: c.Core.With(fields), route: c.route}
+}
+
+func (c *nameRouteCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.route.matches(ent.LoggerName) {
+		return c.Core.Check(ent, ce)
+	}
+	return ce
+}
@@ -0,0 +1,37 @@
+package trace
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithDebugSampling samples Debug entries only, leaving Info and above at full
+// fidelity: within each window, the first entries of each Debug message are logged
+// and after that only every thereafter-th one. This keeps volume down when debug
+// logging is enabled in production, unlike zap's sampler which dampens all levels.
+func WithDebugSampling(first, thereafter int, window time.Duration) Option {
+	return wrapCore(func(core zapcore.Core) zapcore.Core {
+		return &debugSamplingCore{
+			Core:    core,
+			sampled: zapcore.NewSamplerWithOptions(core, window, first, thereafter),
+		}
+	})
+}
+
+// debugSamplingCore routes Debug entries through a sampler over the same core.
+type debugSamplingCore struct {
+	zapcore.Core
+	sampled zapcore.Core
+}
+
+func (c *debugSamplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &debugSamplingCore{Core: c.Core.With(fields), sampled: c.sampled.With(fields)}
+}
+
+func (c *debugSamplingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level == zapcore.DebugLevel {
+		return c.sampled.Check(ent, ce)
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,44 @@
+package trace
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+)
+
+// LogSignal logs the receipt of sig at Warn with its name and, where the platform
+// defines one, its number.
+func LogSignal(logger Logger, sig os.Signal) {
+	if sig == nil {
+		return
+	}
+	fields := []zap.Field{zap.String("signal", sig.String())}
+	if s, ok := sig.(syscall.Signal); ok {
+		fields = append(fields, zap.Int("signal_number", int(s)))
+	}
+	logger.Warn("signal received", fields...)
+}
+
+// LogSignalsUntil logs every received signal in sigs (os.Interrupt and SIGTERM when
+// none are given) with LogSignal until ctx is done. It blocks, so run it on its own goroutine;
+// it stops the signal subscription before returning. Subscribing to a signal changes
+// its default handling, so a SIGINT logged here no longer ends the program by itself.
+func LogSignalsUntil(ctx context.Context, logger Logger, sigs ...os.Signal) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	defer signal.Stop(ch)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-ch:
+			LogSignal(logger, sig)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package trace
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// silenceGate mutes a core while its counter is non-zero.
+// A counter rather than a flag lets overlapping Silence calls compose.
+type silenceGate struct {
+	muted  atomic.Int32
+	parent *silenceGate // gate of the logger this one's was derived from, whose core it wraps
+}
+
+// silenced reports whether g or a gate it is nested in is muted.
+func (g *silenceGate) silenced() bool {
+	for ; g != nil; g = g.parent {
+		if g.muted.Load() != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// silence mutes the gate and returns an idempotent restore func.
+func (g *silenceGate) silence() func() {
+	g.muted.Add(1)
+	var once sync.Once
+	return func() {
+		once.Do(func() { g.muted.Add(-1) })
+	}
+}
+
+// gateCore drops every entry while its gate is muted.
+type gateCore struct {
+	zapcore.Core
+	gate *silenceGate
+}
+
+func (c *gateCore) Enabled(level zapcore.Level) bool {
+	return c.gate.muted.Load() == 0 && c.Core.Enabled(level)
+}
+
+func (c *gateCore) With(fields []zapcore.Field) zapcore.Core {
+	return &gateCore{Core: c.Core.With(fields), gate: c.gate}
+}
+
+func (c *gateCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.gate.muted.Load() != 0 {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
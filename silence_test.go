@@ -0,0 +1,64 @@
+package trace
+
+import (
+	"slices"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSilenceScopedToReceiver(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	root := NewWithCore(core, "")
+	imp := root.Named("import")
+	api := root.Named("api")
+	row := imp.With(Str("table", "users"))
+
+	restore := imp.Silence()
+	imp.Info("import muted")
+	row.Info("import child muted")
+	api.Info("sibling logs")
+	root.Info("parent logs")
+	if got := messages(logs); !slices.Equal(got, []string{"sibling logs", "parent logs"}) {
+		t.Errorf("while silenced got %q", got)
+	}
+	if snap := imp.(*sugarLogger).Snapshot(); snap["silenced"] != true {
+		t.Errorf("Snapshot silenced = %v, want true", snap["silenced"])
+	}
+
+	restore()
+	restore()
+	logs.TakeAll()
+	imp.Info("import restored")
+	if got := messages(logs); !slices.Equal(got, []string{"import restored"}) {
+		t.Errorf("after restore got %q", got)
+	}
+}
+
+func TestSilenceParentMutesChildren(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	root := NewWithCore(core, "")
+	child := root.Named("child").With(Int("n", 1))
+
+	first := root.Silence()
+	second := root.Silence()
+	child.Info("muted")
+	first()
+	first()
+	child.Info("still muted")
+	second()
+	child.Info("logged")
+
+	if got := messages(logs); !slices.Equal(got, []string{"logged"}) {
+		t.Errorf("got %q, want only the entry after both restores", got)
+	}
+}
+
+func messages(logs *observer.ObservedLogs) []string {
+	var msgs []string
+	for _, e := range logs.AllUntimed() {
+		msgs = append(msgs, e.Message)
+	}
+	return msgs
+}
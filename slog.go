@@ -0,0 +1,157 @@
+package trace
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var _ slog.Handler = &slogHandler{}
+
+// NewSlogHandler returns a slog.Handler that writes records through logger's
+// core, so libraries speaking slog share its sinks, level and bound fields.
+// slog groups become nested objects.
+func NewSlogHandler(logger Logger) slog.Handler {
+	z := logger.Zap()
+	return &slogHandler{core: z.Core(), name: z.Name()}
+}
+
+type slogHandler struct {
+	core   zapcore.Core
+	name   string
+	groups []string // groups opened by WithGroup that have no attributes yet
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.core.Enabled(zapLevel(level))
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	ent := zapcore.Entry{
+		Level:      zapLevel(r.Level),
+		Time:       r.Time,
+		LoggerName: h.name,
+		Message:    r.Message,
+	}
+	ce := h.core.Check(ent, nil)
+	if ce == nil {
+		return nil
+	}
+	if r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		ce.Entry.Caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true)
+	}
+
+	fields := make([]zapcore.Field, 0, r.NumAttrs()+len(h.groups))
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendAttr(fields, a)
+		return true
+	})
+	if len(fields) > 0 {
+		fields = append(h.namespaces(), fields...)
+	}
+	ce.Write(fields...)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make([]zapcore.Field, 0, len(attrs))
+	for _, a := range attrs {
+		fields = appendAttr(fields, a)
+	}
+	if len(fields) == 0 {
+		return h
+	}
+	return &slogHandler{core: h.core.With(append(h.namespaces(), fields...)), name: h.name}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	groups := make([]string, 0, len(h.groups)+1)
+	groups = append(groups, h.groups...)
+	return &slogHandler{core: h.core, name: h.name, groups: append(groups, name)}
+}
+
+// namespaces opens the pending groups; they are only applied once attributes follow,
+// matching slog's rule that empty groups are omitted.
+func (h *slogHandler) namespaces() []zapcore.Field {
+	fields := make([]zapcore.Field, len(h.groups))
+	for i, g := range h.groups {
+		fields[i] = zap.Namespace(g)
+	}
+	return fields
+}
+
+// zapLevel maps slog levels, which may lie between the named ones, onto zap levels.
+func zapLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return zapcore.DebugLevel
+	case level < slog.LevelWarn:
+		return zapcore.InfoLevel
+	case level < slog.LevelError:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+func appendAttr(fields []zapcore.Field, a slog.Attr) []zapcore.Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		attrs := a.Value.Group()
+		if len(attrs) == 0 {
+			return fields
+		}
+		if a.Key == "" {
+			for _, ga := range attrs {
+				fields = appendAttr(fields, ga)
+			}
+			return fields
+		}
+		return append(fields, zap.Object(a.Key, slogGroup(attrs)))
+	case slog.KindString:
+		return append(fields, zap.String(a.Key, a.Value.String()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(a.Key, a.Value.Int64()))
+	case slog.KindUint64:
+		return append(fields, zap.Uint64(a.Key, a.Value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(a.Key, a.Value.Float64()))
+	case slog.KindBool:
+		return append(fields, zap.Bool(a.Key, a.Value.Bool()))
+	case slog.KindDuration:
+		return append(fields, zap.Duration(a.Key, a.Value.Duration()))
+	case slog.KindTime:
+		return append(fields, zap.Time(a.Key, a.Value.Time()))
+	default:
+		if err, ok := a.Value.Any().(error); ok {
+			return append(fields, zap.NamedError(a.Key, err))
+		}
+		return append(fields, Any(a.Key, a.Value.Any()))
+	}
+}
+
+// slogGroup encodes the attributes of a slog group as a nested object.
+type slogGroup []slog.Attr
+
+func (g slogGroup) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	var fields []zapcore.Field
+	for _, a := range g {
+		fields = appendAttr(fields, a)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package trace
+
+import "go.uber.org/zap/zapcore"
+
+// Snapshot describes the logger's effective configuration for crash and bug reports:
+// its name, level, output format, whether it is silenced, each sink with the lowest
+// level it accepts, the keys of fields bound via With and the operation path. It
+// answers "why did this not log" from a user-submitted dump without exposing field
+// values.
+func (l *sugarLogger) Snapshot() map[string]interface{} {
+	if l == nil || l.log == nil {
+		return map[string]interface{}{"logger": "nil"}
+	}
+	snap := map[string]interface{}{
+		"logger": "sugar",
+		"name":   l.log.Name(),
+		"level":  l.log.Level().String(),
+	}
+	fields := l.bound
+	if fields == nil {
+		fields = []string{}
+	}
+	snap["fields"] = fields
+	if l.op != "" {
+		snap["op_path"] = l.op
+	}
+	if l.cfg == nil {
+		return snap
+	}
+
+	snap["silenced"] = l.gate.silenced()
+	if l.cfg.json != nil {
+		snap["format"] = FormatConsole
+		if l.cfg.json.Load() {
+			snap["format"] = FormatJSON
+		}
+	}
+	sinks := make([]map[string]string, 0, len(l.cfg.sinks))
+	for _, s := range l.cfg.sinks {
+		sinks = append(sinks, map[string]string{"name": s.name, "level": minLevel(s.level)})
+	}
+	snap["sinks"] = sinks
+	return snap
+}
+
+// minLevel names the lowest level enab accepts, or "disabled" if it accepts none.
+func minLevel(enab zapcore.LevelEnabler) string {
+	for l := zapcore.DebugLevel; l <= zapcore.FatalLevel; l++ {
+		if enab.Enabled(l) {
+			return l.String()
+		}
+	}
+	return "disabled"
+}
@@ -0,0 +1,55 @@
+package trace
+
+import (
+	"sort"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SortedMap logs m as a nested object whose keys are emitted in sorted order,
+// giving stable output for human reading and test matching.
+func SortedMap(key string, m map[string]interface{}) zap.Field {
+	return zap.Object(key, sortedMap[interface{}]{m: m, add: func(enc zapcore.ObjectEncoder, k string, v interface{}) error {
+		return enc.AddReflected(k, v)
+	}})
+}
+
+// SortedStringMap is SortedMap for map[string]string.
+func SortedStringMap(key string, m map[string]string) zap.Field {
+	return zap.Object(key, sortedMap[string]{m: m, add: func(enc zapcore.ObjectEncoder, k string, v string) error {
+		enc.AddString(k, v)
+		return nil
+	}})
+}
+
+// SortedIntMap is SortedMap for map[string]int.
+func SortedIntMap(key string, m map[string]int) zap.Field {
+	return zap.Object(key, sortedMap[int]{m: m, add: func(enc zapcore.ObjectEncoder, k string, v int) error {
+		enc.AddInt(k, v)
+		return nil
+	}})
+}
+
+type sortedMap[V any] struct {
+	m   map[string]V
+	add func(enc zapcore.ObjectEncoder, k string, v V) error
+}
+
+func (s sortedMap[V]) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, k := range sortedKeys(s.m) {
+		if err := s.add(enc, k, s.m[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
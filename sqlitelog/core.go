@@ -0,0 +1,262 @@
+// Package sqlitelog stores trace log entries in an SQLite table so local tools can
+// query their own logs.
+//
+// The package talks to the database through database/sql only and imports no driver;
+// the program picks one (for example modernc.org/sqlite or github.com/mattn/go-sqlite3)
+// and passes the opened *sql.DB, keeping the SQLite dependency out of trace itself.
+//
+//	db, _ := sql.Open("sqlite", "app-logs.db")
+//	core, err := sqlitelog.NewCore(db, "logs", zapcore.DebugLevel)
+//	logger := trace.NewWithCore(core, "app")
+//	defer core.Close()
+package sqlitelog
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Defaults for Options left at zero.
+const (
+	DefaultBatchSize     = 100
+	DefaultFlushInterval = time.Second
+)
+
+var _ zapcore.Core = &Core{}
+
+// tableName restricts table names, which cannot be passed as query parameters.
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Options tunes batching. Entries are inserted in a single transaction once
+// BatchSize entries are pending or FlushInterval has passed since the first one.
+type Options struct {
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// Row is a stored log entry. Fields holds the entry's fields as a JSON object.
+type Row struct {
+	Time    time.Time
+	Level   zapcore.Level
+	Logger  string
+	Message string
+	Fields  string
+}
+
+// Core is a zapcore.Core that inserts entries into an SQLite table in batches.
+type Core struct {
+	zapcore.LevelEnabler
+	store *store
+	enc   zapcore.Encoder // encodes only fields, including those bound via With
+}
+
+// NewCore creates table in db if needed and returns a Core inserting entries at or
+// above enab into it. The table has the columns ts (unix nanoseconds), level (zap
+// level number), logger, msg and fields (JSON); ts and level are indexed for Query.
+func NewCore(db *sql.DB, table string, enab zapcore.LevelEnabler, opts ...Options) (*Core, error) {
+	if !tableName.MatchString(table) {
+		return nil, fmt.Errorf("sqlitelog: invalid table name %q", table)
+	}
+	var o Options
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultBatchSize
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = DefaultFlushInterval
+	}
+
+	schema := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+	ts INTEGER NOT NULL,
+	level INTEGER NOT NULL,
+	logger TEXT NOT NULL,
+	msg TEXT NOT NULL,
+	fields TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS %[1]s_ts_level ON %[1]s (ts, level);`, table)
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("sqlitelog: create table: %w", err)
+	}
+
+	return &Core{
+		LevelEnabler: enab,
+		store: &store{
+			db:       db,
+			insert:   fmt.Sprintf("INSERT INTO %s (ts, level, logger, msg, fields) VALUES (?, ?, ?, ?, ?)", table),
+			query:    fmt.Sprintf("SELECT ts, level, logger, msg, fields FROM %s WHERE level >= ? AND ts >= ? ORDER BY ts", table),
+			size:     o.BatchSize,
+			interval: o.FlushInterval,
+		},
+		enc: zapcore.NewJSONEncoder(zapcore.EncoderConfig{}),
+	}, nil
+}
+
+// With returns a copy of the core with additional fields bound to every entry.
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return &Core{LevelEnabler: c.LevelEnabler, store: c.store, enc: enc}
+}
+
+// Check adds the core to the checked entry if the level is enabled.
+func (c *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write queues the entry for insertion, inserting at once from DPanic up. It returns the error of a failed
+// background insert, if one happened since the last report.
+func (c *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(zapcore.Entry{}, fields)
+	if err != nil {
+		return err
+	}
+	row := Row{
+		Time:    ent.Time,
+		Level:   ent.Level,
+		Logger:  ent.LoggerName,
+		Message: ent.Message,
+		Fields:  strings.TrimSuffix(buf.String(), "\n"),
+	}
+	buf.Free()
+	if err := c.store.add(row); err != nil {
+		return err
+	}
+	if ent.Level > zapcore.ErrorLevel {
+		// Like zap's own cores, flush before a possible panic or exit.
+		return c.Sync()
+	}
+	return nil
+}
+
+// Sync inserts all pending entries.
+func (c *Core) Sync() error {
+	return c.store.flush()
+}
+
+// Close inserts all pending entries. The database is left open.
+func (c *Core) Close() error {
+	return c.Sync()
+}
+
+// Query returns the stored entries at or above level logged at or after since,
+// oldest first. Pending entries are inserted first so they are included.
+func (c *Core) Query(level zapcore.Level, since time.Time) ([]Row, error) {
+	if err := c.store.flush(); err != nil {
+		return nil, err
+	}
+	rows, err := c.store.db.Query(c.store.query, int8(level), since.UnixNano())
+	if err != nil {
+		return nil, fmt.Errorf("sqlitelog: query: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Row
+	for rows.Next() {
+		var (
+			r     Row
+			ts    int64
+			level int8
+		)
+		if err := rows.Scan(&ts, &level, &r.Logger, &r.Message, &r.Fields); err != nil {
+			return nil, fmt.Errorf("sqlitelog: query: %w", err)
+		}
+		r.Time = time.Unix(0, ts)
+		r.Level = zapcore.Level(level)
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlitelog: query: %w", err)
+	}
+	return out, nil
+}
+
+// store batches rows shared by a core and its clones.
+type store struct {
+	db            *sql.DB
+	insert, query string
+	size          int
+	interval      time.Duration
+
+	mu      sync.Mutex
+	pending []Row
+	timer   *time.Timer
+	err     error // error of the last background flush, reported by the next Write
+}
+
+func (s *store) add(r Row) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = append(s.pending, r)
+	if len(s.pending) >= s.size {
+		return s.flushLocked()
+	}
+	if s.timer == nil {
+		s.timer = time.AfterFunc(s.interval, s.flushOnTimer)
+	}
+	err := s.err
+	s.err = nil
+	return err
+}
+
+func (s *store) flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.flushLocked()
+}
+
+func (s *store) flushOnTimer() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timer = nil
+	if err := s.flushLocked(); err != nil {
+		s.err = err
+	}
+}
+
+// flushLocked inserts the pending rows in one transaction. Rows are dropped
+// if the insert fails, so a broken database cannot grow the batch without bound.
+func (s *store) flushLocked() error {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if len(s.pending) == 0 {
+		return nil
+	}
+	rows := s.pending
+	s.pending = nil
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("sqlitelog: insert: %w", err)
+	}
+	stmt, err := tx.Prepare(s.insert)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("sqlitelog: insert: %w", err)
+	}
+	defer stmt.Close()
+	for _, r := range rows {
+		if _, err := stmt.Exec(r.Time.UnixNano(), int8(r.Level), r.Logger, r.Message, r.Fields); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("sqlitelog: insert: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sqlitelog: insert: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package sqlitelog
+
+import (
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/broaskaGit/trace"
+	"go.uber.org/zap/zapcore"
+	_ "modernc.org/sqlite"
+)
+
+func openCore(t *testing.T, opts Options) (*Core, *sql.DB) {
+	t.Helper()
+	db, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "logs.db")+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	core, err := NewCore(db, "logs", zapcore.DebugLevel, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { core.Close() })
+	return core, db
+}
+
+func countRows(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM logs").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestBatchSizeFlush(t *testing.T) {
+	core, db := openCore(t, Options{BatchSize: 3, FlushInterval: time.Hour})
+	logger := trace.NewWithCore(core, "")
+
+	logger.Info("one")
+	logger.Info("two")
+	if n := countRows(t, db); n != 0 {
+		t.Fatalf("%d rows inserted before the batch was full, want 0", n)
+	}
+	logger.Info("three")
+	if n := countRows(t, db); n != 3 {
+		t.Errorf("%d rows after a full batch, want 3", n)
+	}
+}
+
+func TestFlushInterval(t *testing.T) {
+	core, db := openCore(t, Options{BatchSize: 100, FlushInterval: 20 * time.Millisecond})
+	trace.NewWithCore(core, "").Info("pending")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for countRows(t, db) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("entry not inserted after the flush interval")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	core, _ := openCore(t, Options{})
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i, level := range []zapcore.Level{zapcore.InfoLevel, zapcore.ErrorLevel, zapcore.DebugLevel, zapcore.WarnLevel, zapcore.ErrorLevel} {
+		ent := zapcore.Entry{Time: base.Add(time.Duration(i) * time.Minute), Level: level, Message: level.String()}
+		if err := core.Write(ent, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rows, err := core.Query(zapcore.WarnLevel, base.Add(2*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2: %+v", len(rows), rows)
+	}
+	for i, want := range []struct {
+		level zapcore.Level
+		at    time.Time
+	}{{zapcore.WarnLevel, base.Add(3 * time.Minute)}, {zapcore.ErrorLevel, base.Add(4 * time.Minute)}} {
+		if rows[i].Level != want.level || !rows[i].Time.Equal(want.at) {
+			t.Errorf("row %d = %s at %v, want %s at %v", i, rows[i].Level, rows[i].Time, want.level, want.at)
+		}
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	core, _ := openCore(t, Options{})
+	logger := trace.NewWithCore(core, "app").With(trace.Str("request_id", "r1"))
+	logger.Info("handled", trace.Int("status", 200))
+
+	rows, err := core.Query(zapcore.DebugLevel, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	r := rows[0]
+	if r.Logger != "app" || r.Message != "handled" {
+		t.Errorf("row = %+v, want logger app and message handled", r)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(r.Fields), &fields); err != nil {
+		t.Fatalf("fields %q: %v", r.Fields, err)
+	}
+	if fields["request_id"] != "r1" || fields["status"] != float64(200) {
+		t.Errorf("fields = %v, want bound request_id and status", fields)
+	}
+}
@@ -0,0 +1,59 @@
+package trace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// stdoutSink returns a pipe-safe writer to the current os.Stdout, so a logger built
+// after os.Stdout is replaced writes to the replacement.
+func stdoutSink() *pipeSafeSink {
+	return newPipeSafeSink(os.Stdout, "stdout")
+}
+
+// pipeSafeSink stops writing to a destination once it reports a broken or closed pipe,
+// as stdout does when a container's log collector dies, and discards output from then
+// on so the program keeps running. A warning is printed to stderr once.
+type pipeSafeSink struct {
+	ws     zapcore.WriteSyncer
+	name   string
+	broken atomic.Bool
+	warn   sync.Once
+}
+
+func newPipeSafeSink(ws zapcore.WriteSyncer, name string) *pipeSafeSink {
+	ignoreSIGPIPE()
+	return &pipeSafeSink{ws: ws, name: name}
+}
+
+func (s *pipeSafeSink) Write(p []byte) (int, error) {
+	if s.broken.Load() {
+		return len(p), nil
+	}
+	n, err := s.ws.Write(p)
+	if err != nil && isBrokenPipe(err) {
+		s.broken.Store(true)
+		s.warn.Do(func() {
+			fmt.Fprintf(os.Stderr, "trace: %s is closed (%v); discarding its log output\n", s.name, err)
+		})
+		return len(p), nil
+	}
+	return n, err
+}
+
+func (s *pipeSafeSink) Sync() error {
+	if s.broken.Load() {
+		return nil
+	}
+	return s.ws.Sync()
+}
+
+func isBrokenPipe(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, os.ErrClosed)
+}
@@ -0,0 +1,6 @@
+//go:build !unix
+
+package trace
+
+// ignoreSIGPIPE is a no-op on platforms without SIGPIPE.
+func ignoreSIGPIPE() {}
@@ -0,0 +1,21 @@
+//go:build unix
+
+package trace
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+var sigpipeOnce sync.Once
+
+// ignoreSIGPIPE stops a write to a broken stdout pipe from killing the process: once
+// SIGPIPE is subscribed to, the runtime delivers it to the channel and the write
+// fails with EPIPE instead, which pipeSafeSink handles.
+func ignoreSIGPIPE() {
+	sigpipeOnce.Do(func() {
+		signal.Notify(make(chan os.Signal, 1), syscall.SIGPIPE)
+	})
+}
@@ -0,0 +1,52 @@
+package trace
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Stopwatch logs the time between named checkpoints, for profiling multi-stage
+// pipelines inline in the logs:
+//
+//	sw := trace.NewStopwatch(logger)
+//	load()
+//	sw.Checkpoint("load")
+//	transform()
+//	sw.Checkpoint("transform")
+//
+// A Stopwatch is safe for concurrent use; checkpoints are ordered by the time
+// Checkpoint is called.
+type Stopwatch struct {
+	logger Logger
+
+	mu    sync.Mutex
+	start time.Time
+	last  time.Time
+	prev  string // name of the previous checkpoint, empty before the first
+}
+
+// NewStopwatch returns a Stopwatch started now that logs through logger.
+func NewStopwatch(logger Logger) *Stopwatch {
+	now := time.Now()
+	return &Stopwatch{logger: logger, start: now, last: now}
+}
+
+// Checkpoint logs, at Info, the checkpoint name, the previous checkpoint (if any),
+// the time elapsed since it (or since the start) and the total time since the start.
+func (s *Stopwatch) Checkpoint(name string) {
+	s.mu.Lock()
+	now := time.Now()
+	elapsed, total, prev := now.Sub(s.last), now.Sub(s.start), s.prev
+	s.last, s.prev = now, name
+	s.mu.Unlock()
+
+	fields := make([]zap.Field, 0, 4)
+	fields = append(fields, zap.String("checkpoint", name))
+	if prev != "" {
+		fields = append(fields, zap.String("previous", prev))
+	}
+	fields = append(fields, zap.Duration("elapsed", elapsed), zap.Duration("total", total))
+	s.logger.Info("checkpoint", fields...)
+}
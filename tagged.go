@@ -0,0 +1,26 @@
+package trace
+
+import "go.uber.org/zap/zapcore"
+
+// tagCore prepends a literal tag to the message of every entry written.
+type tagCore struct {
+	zapcore.Core
+	prefix string // "[tag] "
+}
+
+func (c *tagCore) With(fields []zapcore.Field) zapcore.Core {
+	return &tagCore{Core: c.Core.With(fields), prefix: c.prefix}
+}
+
+func (c *tagCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *tagCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = c.prefix + ent.Message
+	writeChecked(c.Core, ent, fields)
+	return nil
+}
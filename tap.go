@@ -0,0 +1,10 @@
+package trace
+
+// Tap logs val at Debug under the "value" key and returns it unchanged,
+// so a value can be inspected inline without breaking an expression:
+//
+//	x := trace.Tap(logger, "computed", compute())
+func Tap[T any](logger Logger, msg string, val T) T {
+	logger.Debug(msg, Any("value", val))
+	return val
+}
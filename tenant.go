@@ -0,0 +1,67 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+type tenantCtxKey struct{}
+
+// tenantKey holds the context key TenantField reads; nil means tenantCtxKey{}.
+var tenantKey atomic.Pointer[interface{}]
+
+// SetTenantContextKey makes TenantField read the tenant ID stored under key,
+// for applications that already keep it in the context under their own key:
+//
+//	type ctxKey struct{}
+//	trace.SetTenantContextKey(ctxKey{})
+//	ctx = context.WithValue(ctx, ctxKey{}, "acme")
+//
+// Without a registered key, TenantField reads values stored by ContextWithTenant.
+// Passing nil restores that default.
+func SetTenantContextKey(key interface{}) {
+	if key == nil {
+		tenantKey.Store(nil)
+		return
+	}
+	tenantKey.Store(&key)
+}
+
+func resetTenantContextKey() {
+	tenantKey.Store(nil)
+}
+
+// ContextWithTenant stores the tenant ID in the context under the default key.
+func ContextWithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantCtxKey{}, tenantID)
+}
+
+// TenantField returns a tenant_id field for the tenant stored in ctx, or an empty
+// field (which encoders ignore) when there is none.
+func TenantField(ctx context.Context) zap.Field {
+	if ctx == nil {
+		return zap.Skip()
+	}
+
+	var key interface{} = tenantCtxKey{}
+	if k := tenantKey.Load(); k != nil {
+		key = *k
+	}
+
+	switch v := ctx.Value(key).(type) {
+	case nil:
+		return zap.Skip()
+	case string:
+		if v == "" {
+			return zap.Skip()
+		}
+		return zap.String("tenant_id", v)
+	case fmt.Stringer:
+		return zap.String("tenant_id", v.String())
+	default:
+		return zap.String("tenant_id", fmt.Sprint(v))
+	}
+}
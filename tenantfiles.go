@@ -0,0 +1,205 @@
+package trace
+
+import (
+	"container/list"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultMaxTenantFiles is used by NewTenantCore when maxOpen is not positive.
+const DefaultMaxTenantFiles = 64
+
+var _ zapcore.Core = &TenantCore{}
+
+// TenantCore is a zapcore.Core that writes each entry as JSON to a file of its own
+// tenant, named after the tenant_id field (as added by TenantField), so that
+// per-tenant logs can be delivered or exported without separate loggers:
+//
+//	core := trace.NewTenantCore("/var/log/tenants", 128, trace.InfoLevel, zapcore.AddSync(os.Stdout))
+//	logger := trace.NewWithCore(core, "app")
+//	defer core.Close()
+//	logger.Info("invoice sent", trace.TenantField(ctx)) // /var/log/tenants/acme.log
+//
+// The tenant comes from the entry's fields, or from those bound via With. Entries
+// without a tenant go to the default sink. Files are opened lazily in append mode;
+// at most maxOpen stay open, and the least recently used one is closed when another
+// is needed. Characters other than letters, digits, '.', '-' and '_' in a tenant ID
+// are replaced with '_' in the file name.
+type TenantCore struct {
+	zapcore.LevelEnabler
+	files  *tenantFiles
+	enc    zapcore.Encoder
+	tenant string // bound via With
+}
+
+// NewTenantCore returns a TenantCore writing entries at or above enab to one file
+// per tenant in dir, and entries without a tenant to fallback. A nil fallback
+// discards them. dir must exist.
+func NewTenantCore(dir string, maxOpen int, enab zapcore.LevelEnabler, fallback zapcore.WriteSyncer) *TenantCore {
+	if maxOpen <= 0 {
+		maxOpen = DefaultMaxTenantFiles
+	}
+	if fallback == nil {
+		fallback = zapcore.AddSync(io.Discard)
+	}
+	return &TenantCore{
+		LevelEnabler: enab,
+		files: &tenantFiles{
+			dir:      dir,
+			max:      maxOpen,
+			fallback: zapcore.Lock(fallback),
+			open:     make(map[string]*list.Element),
+			lru:      list.New(),
+		},
+		enc: zapcore.NewJSONEncoder(newJSONEncoderConfig()),
+	}
+}
+
+func (c *TenantCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &TenantCore{LevelEnabler: c.LevelEnabler, files: c.files, enc: c.enc.Clone(), tenant: c.tenant}
+	for _, f := range fields {
+		f.AddTo(clone.enc)
+	}
+	if t := tenantOf(fields); t != "" {
+		clone.tenant = t
+	}
+	return clone
+}
+
+func (c *TenantCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *TenantCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	tenant := tenantOf(fields)
+	if tenant == "" {
+		tenant = c.tenant
+	}
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+	if ent.Level > zapcore.ErrorLevel {
+		defer c.Sync()
+	}
+	return c.files.write(tenant, buf.Bytes())
+}
+
+// Sync flushes the default sink and every open tenant file.
+func (c *TenantCore) Sync() error { return c.files.sync() }
+
+// Close closes every open tenant file. Entries written afterwards reopen them.
+func (c *TenantCore) Close() error { return c.files.closeAll() }
+
+// tenantOf returns the value of the last tenant_id string field in fields.
+func tenantOf(fields []zapcore.Field) string {
+	for i := len(fields) - 1; i >= 0; i-- {
+		if f := fields[i]; f.Key == "tenant_id" && f.Type == zapcore.StringType {
+			return f.String
+		}
+	}
+	return ""
+}
+
+// tenantFiles is the set of open tenant files shared by a TenantCore and its
+// children, kept in least-recently-used order.
+type tenantFiles struct {
+	dir      string
+	max      int
+	fallback zapcore.WriteSyncer
+
+	mu   sync.Mutex
+	open map[string]*list.Element // tenant ID to element holding a *tenantFile
+	lru  *list.List               // most recently used first
+}
+
+type tenantFile struct {
+	tenant string
+	file   *os.File
+}
+
+func (t *tenantFiles) write(tenant string, p []byte) error {
+	if tenant == "" {
+		_, err := t.fallback.Write(p)
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f, err := t.get(tenant)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(p)
+	return err
+}
+
+// get returns the open file of tenant, opening it and closing the least recently
+// used file if needed. t.mu must be held.
+func (t *tenantFiles) get(tenant string) (*os.File, error) {
+	if el, ok := t.open[tenant]; ok {
+		t.lru.MoveToFront(el)
+		return el.Value.(*tenantFile).file, nil
+	}
+
+	path := filepath.Join(t.dir, tenantFileName(tenant))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	for t.lru.Len() >= t.max {
+		oldest := t.lru.Back()
+		tf := t.lru.Remove(oldest).(*tenantFile)
+		delete(t.open, tf.tenant)
+		_ = tf.file.Close()
+	}
+	t.open[tenant] = t.lru.PushFront(&tenantFile{tenant: tenant, file: f})
+	return f, nil
+}
+
+func (t *tenantFiles) sync() error {
+	errs := []error{t.fallback.Sync()}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for el := t.lru.Front(); el != nil; el = el.Next() {
+		errs = append(errs, el.Value.(*tenantFile).file.Sync())
+	}
+	return errors.Join(errs...)
+}
+
+func (t *tenantFiles) closeAll() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var errs []error
+	for el := t.lru.Front(); el != nil; el = el.Next() {
+		errs = append(errs, el.Value.(*tenantFile).file.Close())
+	}
+	t.open = make(map[string]*list.Element)
+	t.lru.Init()
+	return errors.Join(errs...)
+}
+
+// tenantFileName maps a tenant ID to a safe file name within the tenant directory.
+func tenantFileName(tenant string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, tenant)
+	if strings.Trim(name, ".") == "" {
+		name = strings.Repeat("_", len(name))
+	}
+	return name + ".log"
+}
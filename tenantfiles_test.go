@@ -0,0 +1,136 @@
+package trace
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// tenantLines returns the lines written to the file of tenant in dir.
+func tenantLines(t *testing.T, dir, tenant string) []string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(dir, tenantFileName(tenant)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+}
+
+func TestTenantCoreEviction(t *testing.T) {
+	dir := t.TempDir()
+	core := NewTenantCore(dir, 2, zapcore.InfoLevel, nil)
+	defer core.Close()
+	logger := NewWithCore(core, "")
+
+	logger.Info("first", Str("tenant_id", "a"))
+	a := core.files.open["a"].Value.(*tenantFile).file
+	logger.Info("first", Str("tenant_id", "b"))
+	logger.Info("first", Str("tenant_id", "c"))
+
+	if _, ok := core.files.open["a"]; ok || len(core.files.open) != 2 {
+		t.Fatalf("open tenants = %v, want b and c", keys(core.files.open))
+	}
+	if _, err := a.Write([]byte("x")); err == nil {
+		t.Error("evicted file of tenant a is still open")
+	}
+
+	logger.Info("second", Str("tenant_id", "a"))
+	if got := tenantLines(t, dir, "a"); len(got) != 2 || !strings.Contains(got[1], "second") {
+		t.Errorf("tenant a lines after reopen = %q", got)
+	}
+	if _, ok := core.files.open["b"]; ok {
+		t.Error("least recently used tenant b was not evicted")
+	}
+}
+
+func TestTenantCoreReopenAfterClose(t *testing.T) {
+	dir := t.TempDir()
+	core := NewTenantCore(dir, 0, zapcore.InfoLevel, nil)
+	logger := NewWithCore(core, "").With(Str("tenant_id", "acme"))
+
+	logger.Info("before close")
+	if err := core.Close(); err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("after close")
+	defer core.Close()
+
+	got := tenantLines(t, dir, "acme")
+	if len(got) != 2 || !strings.Contains(got[0], "before close") || !strings.Contains(got[1], "after close") {
+		t.Errorf("lines = %q, want both entries", got)
+	}
+}
+
+func TestTenantCoreFallback(t *testing.T) {
+	dir := t.TempDir()
+	var fallback bytes.Buffer
+	core := NewTenantCore(dir, 0, zapcore.InfoLevel, zapcore.AddSync(&fallback))
+	defer core.Close()
+
+	NewWithCore(core, "").Info("no tenant")
+
+	if !strings.Contains(fallback.String(), "no tenant") {
+		t.Errorf("fallback = %q, want the entry", fallback.String())
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("tenant dir has %d files, want none", len(entries))
+	}
+}
+
+func TestTenantFileName(t *testing.T) {
+	tests := map[string]string{
+		"acme":     "acme.log",
+		"..":       "__.log",
+		".":        "_.log",
+		"a/b":      "a_b.log",
+		"../etc":   ".._etc.log",
+		"tenant 1": "tenant_1.log",
+	}
+	for tenant, want := range tests {
+		if got := tenantFileName(tenant); got != want {
+			t.Errorf("tenantFileName(%q) = %q, want %q", tenant, got, want)
+		}
+	}
+}
+
+func TestTenantCoreConcurrent(t *testing.T) {
+	const tenants, perTenant = 8, 50
+	dir := t.TempDir()
+	core := NewTenantCore(dir, 3, zapcore.InfoLevel, nil)
+	defer core.Close()
+	logger := NewWithCore(core, "")
+
+	var wg sync.WaitGroup
+	for i := 0; i < tenants; i++ {
+		wg.Add(1)
+		go func(tenant string) {
+			defer wg.Done()
+			child := logger.With(Str("tenant_id", tenant))
+			for j := 0; j < perTenant; j++ {
+				child.Info("entry", Int("n", j))
+				_ = core.Sync()
+			}
+		}(fmt.Sprintf("t%d", i))
+	}
+	wg.Wait()
+
+	for i := 0; i < tenants; i++ {
+		if got := tenantLines(t, dir, fmt.Sprintf("t%d", i)); len(got) != perTenant {
+			t.Errorf("tenant t%d has %d lines, want %d", i, len(got), perTenant)
+		}
+	}
+}
+
+func keys[V any](m map[string]V) []string {
+	var ks []string
+	for k := range m {
+		ks = append(ks, k)
+	}
+	return ks
+}
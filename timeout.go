@@ -0,0 +1,21 @@
+package trace
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Timeout returns the standard fields describing a client-side deadline:
+// timeout_configured, elapsed and timed_out, which is true when elapsed reached
+// the configured timeout. A non-positive configured timeout means none was set
+// and never counts as timed out.
+//
+//	logger.Warn("upstream call failed", trace.Timeout(client.Timeout, time.Since(start))...)
+func Timeout(configured, elapsed time.Duration) []zap.Field {
+	return []zap.Field{
+		zap.Duration("timeout_configured", configured),
+		zap.Duration("elapsed", elapsed),
+		zap.Bool("timed_out", configured > 0 && elapsed >= configured),
+	}
+}
@@ -0,0 +1,30 @@
+package trace
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+	tests := []struct {
+		name                string
+		configured, elapsed time.Duration
+		want                bool
+	}{
+		{"elapsed exceeds configured", time.Second, 1500 * time.Millisecond, true},
+		{"elapsed equals configured", time.Second, time.Second, true},
+		{"within deadline", time.Second, 200 * time.Millisecond, false},
+		{"no timeout set", 0, time.Hour, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FieldsToMap(Timeout(tt.configured, tt.elapsed)...)
+			if got["timed_out"] != tt.want {
+				t.Errorf("timed_out = %v, want %v", got["timed_out"], tt.want)
+			}
+			if got["timeout_configured"] != tt.configured || got["elapsed"] != tt.elapsed {
+				t.Errorf("fields = %v, want configured %s and elapsed %s", got, tt.configured, tt.elapsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package trace
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TimeRange logs a time window, such as a query's bounds, as a nested object with
+// from, to and duration. A zero bound is omitted, and so is the duration, since an
+// open-ended range has none. When from is after to, the range is logged as given
+// with inverted set to true instead of a negative duration.
+func TimeRange(key string, from, to time.Time) zap.Field {
+	return zap.Object(key, timeRange{from: from, to: to})
+}
+
+type timeRange struct {
+	from, to time.Time
+}
+
+func (r timeRange) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if !r.from.IsZero() {
+		enc.AddTime("from", r.from)
+	}
+	if !r.to.IsZero() {
+		enc.AddTime("to", r.to)
+	}
+	if r.from.IsZero() || r.to.IsZero() {
+		return nil
+	}
+	if r.from.After(r.to) {
+		enc.AddBool("inverted", true)
+		return nil
+	}
+	enc.AddDuration("duration", r.to.Sub(r.from))
+	return nil
+}
@@ -0,0 +1,59 @@
+package trace
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// TraceContext parses a W3C traceparent header (version-traceid-parentid-flags)
+// into trace_id, span_id and trace_flags fields so logs can be joined with traces.
+// Malformed headers yield no fields.
+func TraceContext(traceparent string) []zap.Field {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 {
+		return nil
+	}
+
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	if !isHex(version, 2) || version == "ff" {
+		return nil
+	}
+	// Version 00 has exactly four parts; later versions may append more.
+	if version == "00" && len(parts) != 4 {
+		return nil
+	}
+	if !isHex(traceID, 32) || isZeroHex(traceID) {
+		return nil
+	}
+	if !isHex(spanID, 16) || isZeroHex(spanID) {
+		return nil
+	}
+	if !isHex(flags, 2) {
+		return nil
+	}
+
+	return []zap.Field{
+		zap.String("trace_id", traceID),
+		zap.String("span_id", spanID),
+		zap.String("trace_flags", flags),
+	}
+}
+
+// isHex reports whether s is exactly n lowercase hex characters.
+func isHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func isZeroHex(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
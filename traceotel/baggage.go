@@ -0,0 +1,37 @@
+// Package traceotel connects trace loggers to OpenTelemetry context propagation.
+// It lives in its own package so programs that do not use OpenTelemetry are not
+// forced to depend on it.
+package traceotel
+
+import (
+	"context"
+	"sort"
+
+	"github.com/broaskaGit/trace"
+	"go.opentelemetry.io/otel/baggage"
+	"go.uber.org/zap"
+)
+
+// BaggagePrefix is prepended to the key of every baggage member bound by WithBaggage.
+const BaggagePrefix = "baggage."
+
+// WithBaggage returns a child of logger with every baggage member in ctx bound as a
+// field named "baggage.<key>", surfacing propagated request metadata in its logs.
+// Members are bound in key order. Without baggage, or with a nil ctx, logger is
+// returned unchanged.
+func WithBaggage(ctx context.Context, logger trace.Logger) trace.Logger {
+	if ctx == nil || logger == nil {
+		return logger
+	}
+	members := baggage.FromContext(ctx).Members()
+	if len(members) == 0 {
+		return logger
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Key() < members[j].Key() })
+
+	fields := make([]zap.Field, len(members))
+	for i, m := range members {
+		fields[i] = zap.String(BaggagePrefix+m.Key(), m.Value())
+	}
+	return logger.With(fields...)
+}
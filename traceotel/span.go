@@ -0,0 +1,26 @@
+package traceotel
+
+import (
+	"context"
+
+	"github.com/broaskaGit/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// WithTraceContext returns a child of logger with the trace_id and span_id of the
+// span active in ctx bound as fields, so its logs can be joined with the trace.
+// The field names match those of trace.TraceContext. Without a valid span context,
+// or with a nil ctx, logger is returned unchanged.
+func WithTraceContext(ctx context.Context, logger trace.Logger) trace.Logger {
+	if ctx == nil || logger == nil {
+		return logger
+	}
+	sc := oteltrace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return logger
+	}
+	return logger.With(
+		trace.Str("trace_id", sc.TraceID().String()),
+		trace.Str("span_id", sc.SpanID().String()),
+	)
+}
@@ -0,0 +1,54 @@
+package traceotel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/broaskaGit/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithTraceContext(t *testing.T) {
+	traceID := oteltrace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+	spanID := oteltrace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	valid := oteltrace.ContextWithSpanContext(context.Background(), oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: oteltrace.FlagsSampled,
+	}))
+	invalid := oteltrace.ContextWithSpanContext(context.Background(), oteltrace.SpanContext{})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want map[string]interface{}
+	}{
+		{
+			name: "valid",
+			ctx:  valid,
+			want: map[string]interface{}{"trace_id": traceID.String(), "span_id": spanID.String()},
+		},
+		{name: "invalid", ctx: invalid, want: map[string]interface{}{}},
+		{name: "nil", ctx: nil, want: map[string]interface{}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			logger := trace.NewWithCore(core, "")
+
+			WithTraceContext(tt.ctx, logger).Info("traced")
+
+			got := logs.AllUntimed()[0].ContextMap()
+			if len(got) != len(tt.want) {
+				t.Fatalf("fields = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("%s = %v, want %v", k, got[k], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Package tracetest provides helpers for tests of code that logs through trace.
+// It lives in its own package so the testing package is never linked into programs.
+package tracetest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/broaskaGit/trace"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// AssertNoErrors installs an observing logger as the default logger and returns a
+// func, typically deferred, that restores the previous default and fails tb if any
+// entry at Error or above was logged through it, listing those entries:
+//
+//	defer tracetest.AssertNoErrors(t)()
+//
+// Only logs reaching the default logger (package-level functions and loggers obtained
+// from GetDefaultLogger after the call) are observed. Fatal still exits the process.
+func AssertNoErrors(tb testing.TB) func() {
+	tb.Helper()
+	prev := trace.GetDefaultLogger()
+	core, logs := observer.New(zapcore.DebugLevel)
+	trace.SetDefaultLogger(trace.NewWithCore(core, ""))
+
+	return func() {
+		tb.Helper()
+		trace.SetDefaultLogger(prev)
+
+		errs := logs.Filter(func(e observer.LoggedEntry) bool {
+			return e.Level >= zapcore.ErrorLevel
+		}).All()
+		if len(errs) == 0 {
+			return
+		}
+		var b strings.Builder
+		for _, e := range errs {
+			b.WriteString("\n\t")
+			b.WriteString(e.Level.CapitalString())
+			b.WriteByte(' ')
+			if e.LoggerName != "" {
+				b.WriteString(e.LoggerName)
+				b.WriteByte(' ')
+			}
+			b.WriteString(e.Message)
+			if ctx := e.ContextMap(); len(ctx) > 0 {
+				b.WriteByte(' ')
+				fmt.Fprint(&b, ctx)
+			}
+		}
+		tb.Errorf("trace: %d unexpected error log entries:%s", len(errs), b.String())
+	}
+}
@@ -0,0 +1,51 @@
+package trace
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithTTL returns a child logger that adds fields to its entries only until ttl has
+// passed, for context that goes stale, such as the prefix of a short-lived session
+// token. Entries written afterwards omit those fields but are otherwise unchanged.
+// Unlike With, the fields are encoded again for every entry. A non-positive ttl
+// returns a child without the fields.
+func (l *sugarLogger) WithTTL(ttl time.Duration, fields ...zap.Field) Logger {
+	if l == nil || l.log == nil {
+		return l
+	}
+	expires := time.Now().Add(ttl)
+	return l.derive(func(log *zap.Logger) *zap.Logger {
+		return log.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &ttlCore{Core: core, fields: fields, expires: expires}
+		}))
+	})
+}
+
+// ttlCore adds its fields to entries written before expires.
+type ttlCore struct {
+	zapcore.Core
+	fields  []zapcore.Field
+	expires time.Time
+}
+
+func (c *ttlCore) With(fields []zapcore.Field) zapcore.Core {
+	return &ttlCore{Core: c.Core.With(fields), fields: c.fields, expires: c.expires}
+}
+
+func (c *ttlCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *ttlCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if time.Now().Before(c.expires) {
+		fields = appendFields(c.fields, fields)
+	}
+	writeChecked(c.Core, ent, fields)
+	return nil
+}
@@ -0,0 +1,40 @@
+package trace
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithTTL(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := NewWithCore(core, "").WithTTL(200*time.Millisecond, Str("token", "abc1"))
+
+	logger.Info("fresh")
+	time.Sleep(300 * time.Millisecond)
+	logger.Info("stale", Int("n", 1))
+
+	entries := logs.AllUntimed()
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if m := entries[0].ContextMap(); m["token"] != "abc1" {
+		t.Errorf("before expiry fields = %v, want token", m)
+	}
+	if m := entries[1].ContextMap(); len(m) != 1 || m["n"] != int64(1) {
+		t.Errorf("after expiry fields = %v, want only n", m)
+	}
+}
+
+func TestWithTTLNonPositive(t *testing.T) {
+	for _, ttl := range []time.Duration{0, -time.Second} {
+		core, logs := observer.New(zapcore.DebugLevel)
+		NewWithCore(core, "").WithTTL(ttl, Str("token", "abc1")).Info("entry")
+
+		if m := logs.AllUntimed()[0].ContextMap(); len(m) != 0 {
+			t.Errorf("WithTTL(%s) fields = %v, want none", ttl, m)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package trace
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// BytesPerSec logs a throughput as {"value": bps, "unit": "B/s"}.
+func BytesPerSec(key string, bps float64) zap.Field {
+	return zap.Object(key, unitValue{value: bps, unit: "B/s"})
+}
+
+// Celsius logs a temperature as {"value": c, "unit": "°C"}.
+func Celsius(key string, c float64) zap.Field {
+	return zap.Object(key, unitValue{value: c, unit: "°C"})
+}
+
+// unitValue is a number annotated with its unit, so dashboards need not guess
+// what a bare number means.
+type unitValue struct {
+	value float64
+	unit  string
+}
+
+func (u unitValue) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddFloat64("value", u.value)
+	enc.AddString("unit", u.unit)
+	return nil
+}
@@ -0,0 +1,27 @@
+package trace
+
+import (
+	"reflect"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestUnits(t *testing.T) {
+	tests := []struct {
+		name  string
+		field zap.Field
+		want  map[string]interface{}
+	}{
+		{"BytesPerSec", BytesPerSec("throughput", 1.5e6), map[string]interface{}{"value": 1.5e6, "unit": "B/s"}},
+		{"Celsius", Celsius("temp", -4.5), map[string]interface{}{"value": -4.5, "unit": "°C"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FieldsToMap(tt.field)[tt.field.Key]
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}